@@ -0,0 +1,132 @@
+package nls
+
+import (
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Catalog provides the message templates of one or more languages.
+type Catalog interface {
+	// Lookup returns the template of the message for a key in a language.
+	Lookup(lang, key string) (*template.Template, bool)
+	// Languages returns the languages that have messages, sorted.
+	Languages() []string
+	// Keys returns the keys of all messages, sorted.
+	Keys() []string
+}
+
+// TemplateMap is a Catalog with language-key keys, e.g. "en.hello".
+type TemplateMap map[string]*template.Template
+
+// Lookup returns the template of the message for a key in a language.
+func (m TemplateMap) Lookup(lang, key string) (*template.Template, bool) {
+	tmpl, ok := m[lang+"."+key]
+	return tmpl, ok
+}
+
+// Languages returns the languages that have messages, sorted.
+func (m TemplateMap) Languages() []string {
+	return m.parts(func(lang, key string, _ bool) string { return lang })
+}
+
+// Keys returns the keys of all messages, sorted.
+func (m TemplateMap) Keys() []string {
+	return m.parts(func(lang, key string, _ bool) string { return key })
+}
+
+func (m TemplateMap) parts(part func(lang, key string, found bool) string) []string {
+	seen := map[string]bool{}
+	list := []string{}
+	for mapkey := range m {
+		each := part(strings.Cut(mapkey, "."))
+		if !seen[each] {
+			seen[each] = true
+			list = append(list, each)
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Layers is a Catalog that looks up a message in each of its catalogs in order,
+// such that an application catalog can override the messages of a library catalog, e.g.
+//
+//	nls.NewCatalogLocalizer(nls.Layers{app, library, defaults}, "nl", "en")
+//
+// For each language of a Localizer, all layers are searched before the next language.
+type Layers []Catalog
+
+// Lookup returns the template of the message for a key in a language of the first layer that has one.
+func (l Layers) Lookup(lang, key string) (*template.Template, bool) {
+	for _, each := range l {
+		if tmpl, ok := each.Lookup(lang, key); ok {
+			return tmpl, true
+		}
+	}
+	return nil, false
+}
+
+// Languages returns the languages of all layers, sorted.
+func (l Layers) Languages() []string {
+	return l.union(Catalog.Languages)
+}
+
+// Keys returns the keys of all layers, sorted.
+func (l Layers) Keys() []string {
+	return l.union(Catalog.Keys)
+}
+
+func (l Layers) union(list func(Catalog) []string) []string {
+	seen := map[string]bool{}
+	all := []string{}
+	for _, each := range l {
+		for _, s := range list(each) {
+			if !seen[s] {
+				seen[s] = true
+				all = append(all, s)
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// Completeness is the number of translated messages of a language, e.g. to hide languages that are not ready.
+type Completeness struct {
+	Translated int
+	Total      int
+}
+
+// CatalogCompleteness returns per language of the catalog the number of keys that have a message
+// in the language or in its base language, e.g. en for en-GB, and the number of all keys.
+func CatalogCompleteness(catalog Catalog) map[string]Completeness {
+	keys := catalog.Keys()
+	result := map[string]Completeness{}
+	for _, lang := range catalog.Languages() {
+		c := Completeness{Total: len(keys)}
+		for _, key := range keys {
+			for each := lang; ; {
+				if _, ok := catalog.Lookup(each, key); ok {
+					c.Translated++
+					break
+				}
+				i := strings.LastIndex(each, "-")
+				if i < 0 {
+					break
+				}
+				each = each[:i]
+			}
+		}
+		result[lang] = c
+	}
+	return result
+}
+
+// Ratio returns the fraction of the messages that is translated, 1 if there are none.
+func (c Completeness) Ratio() float64 {
+	if c.Total == 0 {
+		return 1
+	}
+	return float64(c.Translated) / float64(c.Total)
+}
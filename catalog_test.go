@@ -0,0 +1,162 @@
+package nls
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+// upperCatalog is a Catalog that is not a Table nor a TemplateMap.
+type upperCatalog struct{ TemplateMap }
+
+func (c upperCatalog) Lookup(lang, key string) (*template.Template, bool) {
+	return c.TemplateMap.Lookup(lang, strings.ToLower(key))
+}
+
+func TestTemplateMap(t *testing.T) {
+	cat := TemplateMap{
+		"nl.hello": mustTemplate("hallo"),
+		"en.hello": mustTemplate("hello"),
+		"en.sea":   mustTemplate("{{.color}} sea"),
+	}
+	if got, want := strings.Join(cat.Languages(), ","), "en,nl"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := strings.Join(cat.Keys(), ","), "hello,sea"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if _, ok := cat.Lookup("nl", "sea"); ok {
+		t.Error("unexpected template")
+	}
+}
+
+func TestTableCatalog(t *testing.T) {
+	table := NewTable("sea", "hello", "sky")
+	table.Register(0, "en", "{{.color}} sea")
+	table.RegisterText(1, "nl", "hallo")
+	table.RegisterLazy(2, "nl", "{{.color}} lucht")
+	if got, want := strings.Join(table.Languages(), ","), "en,nl"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := strings.Join(table.Keys(), ","), "hello,sea,sky"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if _, ok := table.Lookup("nl", "sea"); ok {
+		t.Error("unexpected template")
+	}
+	for _, key := range []string{"hello", "sky"} {
+		tmpl, ok := table.Lookup("nl", key)
+		if !ok {
+			t.Fatalf("missing template for %s", key)
+		}
+		b := new(strings.Builder)
+		if err := tmpl.Execute(b, map[string]any{"color": "blauwe"}); err != nil {
+			t.Error(err)
+		}
+		if b.Len() == 0 {
+			t.Errorf("empty result for %s", key)
+		}
+	}
+}
+
+func TestCatalogLocalizer(t *testing.T) {
+	cat := upperCatalog{TemplateMap{
+		"en.hello": mustTemplate("hello"),
+		"nl.sea":   mustTemplate("{{.color}} zee"),
+	}}
+	l := NewCatalogLocalizer(cat, "nl", "en")
+	if got, want := l.Get("HELLO"), "hello"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Format("Sea", "color", "blauwe"), "blauwe zee"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Get("sky", "lucht"), "lucht"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+}
+
+func TestLayers(t *testing.T) {
+	app := TemplateMap{
+		"nl.save": mustTemplate("Bewaren"),
+	}
+	library := TemplateMap{
+		"en.save":   mustTemplate("Save"),
+		"nl.save":   mustTemplate("Opslaan"),
+		"en.cancel": mustTemplate("Cancel"),
+	}
+	defaults := TemplateMap{
+		"de.cancel": mustTemplate("Abbrechen"),
+	}
+	layers := Layers{app, library, defaults}
+	if got, want := strings.Join(layers.Languages(), ","), "de,en,nl"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := strings.Join(layers.Keys(), ","), "cancel,save"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	l := NewCatalogLocalizer(layers, "nl", "en")
+	if got, want := l.Get("save"), "Bewaren"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Get("cancel"), "Cancel"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	l = NewCatalogLocalizer(layers, "de", "en")
+	if got, want := l.Get("save"), "Save"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+}
+
+func TestCatalogCompleteness(t *testing.T) {
+	cat := TemplateMap{
+		"en.hello":    mustTemplate("hello"),
+		"en.color":    mustTemplate("color"),
+		"en-GB.color": mustTemplate("colour"),
+		"nl.hello":    mustTemplate("hallo"),
+	}
+	got := CatalogCompleteness(cat)
+	for lang, want := range map[string]Completeness{
+		"en":    {Translated: 2, Total: 2},
+		"en-GB": {Translated: 2, Total: 2},
+		"nl":    {Translated: 1, Total: 2},
+	} {
+		if got[lang] != want {
+			t.Errorf("%s: got [%v] want [%v]", lang, got[lang], want)
+		}
+	}
+}
+
+func TestCompletenessRatio(t *testing.T) {
+	if got, want := (Completeness{Translated: 9, Total: 10}).Ratio(), 0.9; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := (Completeness{}).Ratio(), 1.0; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
+
+func TestRegisterMap(t *testing.T) {
+	// as generated by earlier versions of the nls tool
+	messages := make(map[string]*template.Template, 1)
+	Register(messages, "en.hello", "hello {{.name}}")
+	if got, want := NewLocalizer(messages, "en").Format("hello", "name", "Sam"), "hello Sam"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := NewCatalogLocalizer(TemplateMap(messages), "en").Get("hello"), "hello <no value>"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	m, ok := NewLocalizer(messages, "en").(localizer).findMessage("hello")
+	if !ok || m.static {
+		t.Errorf("got [%v] want template", m)
+	}
+	// sees messages added after it was created
+	l := NewLocalizer(messages, "en")
+	Register(messages, "en.bye", "bye")
+	if got, want := l.Get("bye"), "bye"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if m, _ := l.(localizer).findMessage("bye"); !m.static || m.text != "bye" {
+		t.Errorf("got [%v] want static text", m)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// exportAndroid writes a res/values-xx/strings.xml per language.
+// Placeholders are written as positional arguments %1$s in sorted order of their names;
+// the count of a plural is written as %1$d. Descriptions become comments for translators.
+func exportAndroid(entries []Entry, out string) error {
+	var skipped refused
+	list, params := analyseEntries(entries, &skipped)
+	skipped.report("android")
+	for lang, group := range groupByLanguage(list) {
+		dirs := []string{}
+		qualifier, err := androidQualifier(lang)
+		if err != nil {
+			return err
+		}
+		dirs = append(dirs, "values-"+qualifier)
+		if lang == *oSource {
+			dirs = append(dirs, "values")
+		}
+		content := androidResources(group, params)
+		for _, dir := range dirs {
+			fileName := filepath.Join(out, dir, "strings.xml")
+			if *oVerbose {
+				log.Printf("writing %s\n", fileName)
+			}
+			if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+				return err
+			}
+			if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func androidResources(entries []analysedEntry, params map[string][]string) string {
+	b := new(strings.Builder)
+	fmt.Fprintln(b, `<?xml version="1.0" encoding="utf-8"?>`)
+	fmt.Fprintln(b, "<resources>")
+	for _, each := range entries {
+		if each.Description != "" {
+			fmt.Fprintf(b, "    <!-- %s -->\n", strings.ReplaceAll(each.Description, "--", "- -"))
+		}
+		names := params[each.Key]
+		formatted := len(names) > 0
+		countParam := ""
+		if each.Plural != nil {
+			countParam = each.Plural.Param
+		}
+		arg := func(name string) string {
+			verb := "s"
+			if name == countParam {
+				verb = "d"
+			}
+			return fmt.Sprintf("%%%d$%s", slices.Index(names, name)+1, verb)
+		}
+		name := androidName(each.Key)
+		if each.Plural == nil {
+			fmt.Fprintf(b, "    <string name=\"%s\">%s</string>\n", name, androidText(each.Segments, formatted, arg))
+			continue
+		}
+		fmt.Fprintf(b, "    <plurals name=\"%s\">\n", name)
+		fmt.Fprintf(b, "        <item quantity=\"one\">%s</item>\n", androidText(each.Plural.One, formatted, arg))
+		fmt.Fprintf(b, "        <item quantity=\"other\">%s</item>\n", androidText(each.Plural.Other, formatted, arg))
+		fmt.Fprintln(b, "    </plurals>")
+	}
+	fmt.Fprintln(b, "</resources>")
+	return b.String()
+}
+
+var androidEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+// androidText returns the escaped text with placeholders written by arg.
+func androidText(segments []segment, formatted bool, arg func(string) string) string {
+	escaped := []segment{}
+	for _, each := range segments {
+		if each.Param == "" {
+			each.Text = androidEscaper.Replace(each.Text)
+			if formatted {
+				each.Text = strings.ReplaceAll(each.Text, "%", "%%")
+			}
+		}
+		escaped = append(escaped, each)
+	}
+	text := formatSegments(escaped, arg)
+	if strings.HasPrefix(text, "@") || strings.HasPrefix(text, "?") {
+		text = `\` + text
+	}
+	return text
+}
+
+var nonResourceName = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// androidName returns the key as a valid resource name.
+func androidName(key string) string {
+	return nonResourceName.ReplaceAllString(key, "_")
+}
+
+// androidQualifier returns the resource qualifier of a language, e.g. nl, en-rGB or b+sr+Latn
+func androidQualifier(lang string) (string, error) {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return "", fmt.Errorf("invalid language directory %q: %w", lang, err)
+	}
+	base, script, region := tag.Raw()
+	if script.String() != "Zzzz" {
+		parts := []string{"b", base.String(), script.String()}
+		if region.String() != "ZZ" {
+			parts = append(parts, region.String())
+		}
+		return strings.Join(parts, "+"), nil
+	}
+	if region.String() != "ZZ" {
+		return base.String() + "-r" + region.String(), nil
+	}
+	return base.String(), nil
+}
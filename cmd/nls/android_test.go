@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestAndroidResources(t *testing.T) {
+	var skipped refused
+	list, params := analyseEntries([]Entry{
+		{Language: "en", Key: "quote", Text: `It's "100%" <b> & more`, Description: "a -- comment"},
+		{Language: "en", Key: "greeting", Text: "{{.name}} has {{.count}}% left\n@{{.name}}"},
+		{Language: "en", Key: "app.cats", Text: "{{if eq .n 1}}one cat of {{.name}}{{else}}{{.n}} cats of {{.name}}{{end}}"},
+		{Language: "en", Key: "at", Text: "@home"},
+	}, &skipped)
+	got := androidResources(list, params)
+	want := `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <!-- a - - comment -->
+    <string name="quote">It\'s \"100%\" &lt;b&gt; &amp; more</string>
+    <string name="greeting">%2$s has %1$s%% left\n@%2$s</string>
+    <plurals name="app_cats">
+        <item quantity="one">one cat of %2$s</item>
+        <item quantity="other">%1$d cats of %2$s</item>
+    </plurals>
+    <string name="at">\@home</string>
+</resources>
+`
+	if got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// exportARB writes an app_xx.arb per language for Flutter.
+// Placeholders are written as {name} and plurals as {count, plural, =1{...} other{...}}.
+// Descriptions and placeholders are written in the @key metadata.
+func exportARB(entries []Entry, out string) error {
+	var skipped refused
+	list, params := analyseEntries(entries, &skipped)
+	skipped.report("arb")
+	if err := os.MkdirAll(out, os.ModePerm); err != nil {
+		return err
+	}
+	for lang, group := range groupByLanguage(list) {
+		b := new(strings.Builder)
+		fmt.Fprintf(b, "{\n  \"@@locale\": %s", jsonString(strings.ReplaceAll(lang, "-", "_")))
+		for _, each := range group {
+			fmt.Fprintf(b, ",\n  %s: %s", jsonString(each.Key), jsonString(icuText(each.message)))
+			meta := map[string]any{}
+			if each.Description != "" {
+				meta["description"] = each.Description
+			}
+			if names := params[each.Key]; len(names) > 0 {
+				placeholders := map[string]any{}
+				for _, name := range names {
+					kind := "String"
+					if each.Plural != nil && each.Plural.Param == name {
+						kind = "int"
+					}
+					placeholders[name] = map[string]string{"type": kind}
+				}
+				meta["placeholders"] = placeholders
+			}
+			if len(meta) > 0 {
+				data, err := json.Marshal(meta)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(b, ",\n  %s: %s", jsonString("@"+each.Key), data)
+			}
+		}
+		fmt.Fprintln(b, "\n}")
+		fileName := filepath.Join(out, fmt.Sprintf("app_%s.arb", strings.ReplaceAll(lang, "-", "_")))
+		if *oVerbose {
+			log.Printf("writing %s\n", fileName)
+		}
+		if err := os.WriteFile(fileName, []byte(b.String()), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsonString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// icuText returns the message in ICU syntax.
+func icuText(m message) string {
+	arg := func(name string) string { return "{" + name + "}" }
+	if m.Plural == nil {
+		return formatSegments(m.Segments, arg)
+	}
+	return fmt.Sprintf("{%s, plural, =1{%s} other{%s}}",
+		m.Plural.Param, formatSegments(m.Plural.One, arg), formatSegments(m.Plural.Other, arg))
+}
+
+// importARB reads a .arb file or all .arb files in a directory.
+// The language is taken from @@locale or else from the file name, e.g. app_en_GB.arb
+func importARB(in string, existing []Entry) ([]Entry, error) {
+	files := []string{in}
+	if info, err := os.Stat(in); err != nil {
+		return nil, err
+	} else if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(in, "*.arb"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var entries []Entry
+	var skipped refused
+	for _, each := range files {
+		data, err := os.ReadFile(each)
+		if err != nil {
+			return nil, err
+		}
+		arb := map[string]any{}
+		if err := json.Unmarshal(data, &arb); err != nil {
+			return nil, fmt.Errorf("cannot read %s: %w", each, err)
+		}
+		lang, _ := arb["@@locale"].(string)
+		if lang == "" {
+			name := strings.TrimSuffix(filepath.Base(each), ".arb")
+			if i := strings.Index(name, "_"); i != -1 {
+				lang = name[i+1:]
+			}
+		}
+		if lang == "" {
+			return nil, fmt.Errorf("cannot determine language of %s", each)
+		}
+		lang = strings.ReplaceAll(lang, "_", "-")
+		for key, value := range arb {
+			text, ok := value.(string)
+			if !ok || strings.HasPrefix(key, "@") {
+				continue
+			}
+			entry := Entry{Language: lang, Key: key}
+			if meta, ok := arb["@"+key].(map[string]any); ok {
+				entry.Description, _ = meta["description"].(string)
+			}
+			entry.Text, err = icuToTemplate(text)
+			if err != nil {
+				skipped.add(entry, err)
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+	skipped.report("templates")
+	return entries, nil
+}
+
+// icuToTemplate converts an ICU message with {name} arguments and
+// {count, plural, =1{...} other{...}} into a Go template.
+func icuToTemplate(s string) (string, error) {
+	p := &icuParser{input: []rune(s)}
+	return p.parse(false)
+}
+
+type icuParser struct {
+	input  []rune
+	pos    int
+	plural string // name of the count argument of the enclosing plural
+}
+
+// parse reads until the end of input or, if nested, until the closing brace which is not consumed.
+func (p *icuParser) parse(nested bool) (string, error) {
+	b := new(strings.Builder)
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		switch {
+		case r == '{':
+			p.pos++
+			arg, err := p.argument()
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(arg)
+			continue
+		case r == '}':
+			if nested {
+				return b.String(), nil
+			}
+			return "", fmt.Errorf("unexpected } at %d", p.pos)
+		case r == '#' && p.plural != "":
+			b.WriteString("{{." + p.plural + "}}")
+		default:
+			b.WriteRune(r)
+		}
+		p.pos++
+	}
+	if nested {
+		return "", fmt.Errorf("missing }")
+	}
+	return b.String(), nil
+}
+
+// readUntil returns the trimmed text up to one of the stop runes, which is not consumed.
+func (p *icuParser) readUntil(stops string) (string, error) {
+	start := p.pos
+	for p.pos < len(p.input) {
+		if strings.ContainsRune(stops, p.input[p.pos]) {
+			return strings.TrimSpace(string(p.input[start:p.pos])), nil
+		}
+		p.pos++
+	}
+	return "", fmt.Errorf("missing one of %q", stops)
+}
+
+// argument reads after the opening brace up to and including the closing brace.
+func (p *icuParser) argument() (string, error) {
+	name, err := p.readUntil(",}")
+	if err != nil {
+		return "", err
+	}
+	if p.input[p.pos] == '}' {
+		p.pos++
+		return "{{." + name + "}}", nil
+	}
+	p.pos++ // ,
+	kind, err := p.readUntil(",}")
+	if err != nil {
+		return "", err
+	}
+	if kind != "plural" {
+		return "", fmt.Errorf("unsupported argument type %q", kind)
+	}
+	if p.input[p.pos] == '}' {
+		return "", fmt.Errorf("plural %s has no forms", name)
+	}
+	p.pos++ // ,
+	outer := p.plural
+	p.plural = name
+	defer func() { p.plural = outer }()
+	forms := map[string]string{}
+	for {
+		selector, err := p.readUntil("{}")
+		if err != nil {
+			return "", err
+		}
+		if p.input[p.pos] == '}' {
+			p.pos++
+			break
+		}
+		p.pos++ // {
+		text, err := p.parse(true)
+		if err != nil {
+			return "", err
+		}
+		p.pos++ // }
+		switch selector {
+		case "=1", "one":
+			forms["one"] = text
+		case "other":
+			forms["other"] = text
+		default:
+			return "", fmt.Errorf("unsupported plural form %q of %s", selector, name)
+		}
+	}
+	other, ok := forms["other"]
+	if !ok {
+		return "", fmt.Errorf("plural %s has no other form", name)
+	}
+	one, ok := forms["one"]
+	if !ok {
+		return other, nil
+	}
+	return fmt.Sprintf("{{if eq .%s 1}}%s{{else}}%s{{end}}", name, one, other), nil
+}
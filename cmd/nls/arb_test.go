@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestICUToTemplate(t *testing.T) {
+	for icu, want := range map[string]string{
+		"hello {name}!": "hello {{.name}}!",
+		"{count, plural, =1{one cat} other{{count} cats}}": "{{if eq .count 1}}one cat{{else}}{{.count}} cats{{end}}",
+		"{n, plural, one{# item} other{# items}} left":     "{{if eq .n 1}}{{.n}} item{{else}}{{.n}} items{{end}} left",
+		"{n, plural, other{# items}}":                      "{{.n}} items",
+	} {
+		got, err := icuToTemplate(icu)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("got [%s] want [%s]", got, want)
+		}
+	}
+	for _, icu := range []string{"{n, plural, few{x} other{y}}", "{g, select, male{he} other{they}}", "{name"} {
+		if _, err := icuToTemplate(icu); err == nil {
+			t.Errorf("expected error for [%s]", icu)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// csvRow holds the entries of one key in each language.
+type csvRow struct {
+	Key         string
+	Description string
+	Comment     string
+	Texts       map[string]string // per language
+}
+
+// csvRows returns the rows sorted by key and the sorted languages of the entries.
+func csvRows(entries []Entry) ([]*csvRow, []string) {
+	entries = slices.Clone(entries)
+	sortEntries(entries)
+	languages := []string{}
+	rows := map[string]*csvRow{}
+	for _, each := range entries {
+		if !slices.Contains(languages, each.Language) {
+			languages = append(languages, each.Language)
+		}
+		row, ok := rows[each.Key]
+		if !ok {
+			row = &csvRow{Key: each.Key, Texts: map[string]string{}}
+			rows[each.Key] = row
+		}
+		if row.Description == "" {
+			row.Description = each.Description
+		}
+		if row.Comment == "" {
+			row.Comment = each.Comment
+		}
+		row.Texts[each.Language] = each.Text
+	}
+	list := []*csvRow{}
+	for _, each := range rows {
+		list = append(list, each)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list, languages
+}
+
+// hash returns a short content hash of the row for the given languages.
+func (r *csvRow) hash(languages []string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s", r.Key, r.Description, r.Comment)
+	for _, lang := range languages {
+		fmt.Fprintf(h, "\x00%s=%s", lang, r.Texts[lang])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// exportCSV writes messages.csv with one row per key, a column per language
+// and a hash of the row content to detect changes of the YAML files on import.
+func exportCSV(entries []Entry, out string) error {
+	rows, languages := csvRows(entries)
+	if err := os.MkdirAll(out, os.ModePerm); err != nil {
+		return err
+	}
+	fileName := filepath.Join(out, "messages.csv")
+	if *oVerbose {
+		log.Printf("writing %s\n", fileName)
+	}
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	w := csv.NewWriter(file)
+	header := append(append([]string{"key", "description", "comment"}, languages...), "hash")
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, each := range rows {
+		record := []string{each.Key, each.Description, each.Comment}
+		for _, lang := range languages {
+			record = append(record, each.Texts[lang])
+		}
+		record = append(record, each.hash(languages))
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// importCSV reads a file written by exportCSV and returns the entries of the changed cells,
+// including cells that were cleared.
+// A row is edited if its content no longer matches its hash column; other rows are skipped.
+// An edited row whose key has also changed in the YAML files since the export is
+// reported as a conflict and is not imported.
+func importCSV(in string, existing []Entry) ([]Entry, error) {
+	file, err := os.Open(in)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	header := records[0]
+	if len(header) < 4 || header[0] != "key" || header[len(header)-1] != "hash" {
+		return nil, fmt.Errorf("%s has no key,description,comment,<languages...>,hash header", in)
+	}
+	languages := header[3 : len(header)-1]
+	rows, _ := csvRows(existing)
+	current := map[string]*csvRow{}
+	for _, each := range rows {
+		current[each.Key] = each
+	}
+	entries := map[string]Entry{}
+	for _, each := range existing {
+		entries[each.Language+"."+each.Key] = each
+	}
+	imported := []Entry{}
+	conflicts := []string{}
+	for _, record := range records[1:] {
+		row := &csvRow{Key: record[0], Description: record[1], Comment: record[2], Texts: map[string]string{}}
+		for i, lang := range languages {
+			row.Texts[lang] = record[3+i]
+		}
+		exported := record[len(record)-1]
+		if row.hash(languages) == exported {
+			continue // not edited
+		}
+		row.Comment = yamlComment(row.Comment)
+		yaml, ok := current[row.Key]
+		if !ok {
+			yaml = &csvRow{Key: row.Key, Texts: map[string]string{}}
+		}
+		changes := []Entry{}
+		for _, lang := range languages {
+			textChanged := row.Texts[lang] != yaml.Texts[lang]
+			commentChanged := row.Description != yaml.Description || row.Comment != yaml.Comment
+			if !textChanged && !commentChanged {
+				continue
+			}
+			// the entry replaces the existing one such that cleared cells are imported
+			old := entries[lang+"."+row.Key]
+			change := Entry{Language: lang, Key: row.Key, Text: row.Texts[lang], Description: old.Description, Comment: old.Comment, Replace: true}
+			if commentChanged {
+				change.Description, change.Comment = row.Description, row.Comment
+			}
+			changes = append(changes, change)
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if ok && yaml.hash(languages) != exported {
+			conflicts = append(conflicts, row.Key)
+			continue
+		}
+		imported = append(imported, changes...)
+	}
+	if len(conflicts) > 0 {
+		log.Printf("%d rows changed in both the YAML files and %s and are skipped:\n", len(conflicts), in)
+		for _, each := range conflicts {
+			log.Printf("\t%s\n", each)
+		}
+	}
+	return imported, nil
+}
+
+// yamlComment returns the comment with each line starting with a #
+func yamlComment(comment string) string {
+	if comment == "" {
+		return ""
+	}
+	lines := strings.Split(comment, "\n")
+	for i, each := range lines {
+		if !strings.HasPrefix(each, "#") {
+			lines[i] = "# " + each
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestImportCSV(t *testing.T) {
+	existing := []Entry{
+		{Language: "en", Key: "hello", Text: "hello"},
+		{Language: "nl", Key: "hello", Text: "hallo"},
+		{Language: "en", Key: "world", Text: "world"},
+		{Language: "en", Key: "sea", Text: "sea"},
+		{Language: "en", Key: "sky", Text: "sky", Description: "above"},
+		{Language: "nl", Key: "sky", Text: "lucht", Description: "above"},
+	}
+	rows, languages := csvRows(existing)
+	hashes := map[string]string{}
+	for _, each := range rows {
+		hashes[each.Key] = each.hash(languages)
+	}
+	// the YAML of world and sea changed after the export
+	existing[2].Text = "World"
+	existing[3].Text = "Sea"
+	content := "key,description,comment,en,nl,hash\n" +
+		"hello,,,hello,Hallo,\"" + hashes["hello"] + "\"\n" +
+		"world,,,world,wereld," + hashes["world"] + "\n" +
+		"sea,,,sea,," + hashes["sea"] + "\n" +
+		"sky,,,sky,," + hashes["sky"] + "\n"
+	in := filepath.Join(t.TempDir(), "messages.csv")
+	if err := os.WriteFile(in, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	logged := new(strings.Builder)
+	log.SetOutput(logged)
+	defer log.SetOutput(os.Stderr)
+	imported, err := importCSV(in, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// sea is not edited in the CSV so it is no conflict
+	if got, want := logged.String(), "1 rows changed"; !strings.Contains(got, want) || strings.Contains(got, "sea") {
+		t.Errorf("got [%s] want [%s] for world only", got, want)
+	}
+	if got, want := len(imported), 3; got != want {
+		t.Fatalf("got [%d] want [%d]", got, want)
+	}
+	if got, want := imported[0], (Entry{Language: "nl", Key: "hello", Text: "Hallo", Replace: true}); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	// the cleared description and translation of sky
+	merged := mergeEntries(existing, imported)
+	if got, want := merged[4], (Entry{Language: "en", Key: "sky", Text: "sky"}); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	if got, want := merged[5], (Entry{Language: "nl", Key: "sky"}); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
@@ -1,13 +1,44 @@
 package main
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/emicklei/nls/internal/messagefile"
+)
 
 type Entry struct {
-	Language    string
-	Key         string
-	Text        string
-	Description string
-	Comment     string
+	Language      string
+	Key           string
+	Text          string
+	Description   string
+	Comment       string
+	File          string // from which the entry is read, empty if added
+	State         string // empty if not set explicitly
+	SourceHash    string // of the source language text that the text is translated from
+	OldSourceHash string // of the source language text before it changed, until the text is reviewed
+	Replace       bool   // if imported, an empty text, description or comment replaces the existing one
+}
+
+// effectiveState returns the explicit state of the entry or, if absent, the state implied by its text.
+// Messages of the source language are approved unless their state is set.
+func (e Entry) effectiveState() string {
+	if e.State == "" && e.Text != "" && e.Language == *oSource {
+		return messagefile.StateApproved
+	}
+	return e.message().EffectiveState()
+}
+
+// isRegistered returns whether the entry has a text, or is intentionally empty,
+// and has at least the minimum state for generating.
+func (e Entry) isRegistered() bool {
+	if e.Text == "" && !e.message().IsIntentionallyEmpty() {
+		return false
+	}
+	return messagefile.Progress(e.effectiveState()) >= messagefile.Progress(*oMinState)
+}
+
+func (e Entry) message() messagefile.Message {
+	return messagefile.Message{Key: e.Key, Text: e.Text, Description: e.Description, Comment: e.Comment, State: e.State}
 }
 
 func (e Entry) Replacements() int {
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// embedDir returns the directory of the message files relative to the package directory.
+// Message files outside the package directory cannot be embedded; they are not copied
+// such that a translation change only shows up in one file.
+func embedDir() (string, error) {
+	rel, err := filepath.Rel(abs(*oPkg), abs(*oDir))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("-embed needs the messages directory %s inside the package directory %s, e.g. -dir %s",
+			*oDir, *oPkg, filepath.Join(*oPkg, "messages"))
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func abs(path string) string {
+	a, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return a
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+)
+
+// exporter writes the entries of all languages in a target format into a directory.
+type exporter func(entries []Entry, out string) error
+
+var exporters = map[string]exporter{
+	"i18next":   exportI18next,
+	"android":   exportAndroid,
+	"xcstrings": exportXCStrings,
+	"arb":       exportARB,
+	"csv":       exportCSV,
+	"tmx":       exportTMX,
+}
+
+var (
+	oNested = new(bool)
+)
+
+// nls export -dir messages -format i18next -out web/locales
+func exportCommand(args []string) error {
+	set := flag.NewFlagSet("export", flag.ExitOnError)
+	set.StringVar(oDir, "dir", "", "directory to scan for .yaml files")
+	set.BoolVar(oVerbose, "v", false, "verbose output")
+	format := set.String("format", "i18next", "target format")
+	out := set.String("out", "", "directory to write the exported files")
+	set.BoolVar(oNested, "nested", false, "i18next: nest keys by their dot separated parts")
+	set.StringVar(oSource, "source", "en", "source language; android: also written to res/values")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+	export, ok := exporters[*format]
+	if !ok {
+		return fmt.Errorf("unknown export format %q", *format)
+	}
+	if *out == "" {
+		return errors.New("missing -out directory")
+	}
+	entries, err := loadEntries(*oDir)
+	if err != nil {
+		return err
+	}
+	sortEntries(entries)
+	return export(entries, *out)
+}
+
+// sortEntries sorts by language and key.
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Language != entries[j].Language {
+			return entries[i].Language < entries[j].Language
+		}
+		return entries[i].Key < entries[j].Key
+	})
+}
+
+// refused collects the messages that cannot be exported and reports them.
+type refused []string
+
+func (r *refused) add(e Entry, err error) {
+	*r = append(*r, fmt.Sprintf("%s.%s: %v", e.Language, e.Key, err))
+}
+
+func (r refused) report(format string) {
+	if len(r) == 0 {
+		return
+	}
+	log.Printf("%d messages cannot be expressed in %s and are skipped:\n", len(r), format)
+	for _, each := range r {
+		log.Printf("\t%s\n", each)
+	}
+}
+
+// analysedEntry is an entry with its analysed message.
+type analysedEntry struct {
+	Entry
+	message
+}
+
+// analyseEntries returns the non-empty entries that can be analysed and
+// the sorted placeholder names per key, taken from all languages so
+// positional arguments are the same in each language.
+func analyseEntries(entries []Entry, skipped *refused) ([]analysedEntry, map[string][]string) {
+	list := []analysedEntry{}
+	params := map[string][]string{}
+	for _, each := range entries {
+		if each.Text == "" {
+			continue
+		}
+		msg, err := analyseMessage(each.Text)
+		if err != nil {
+			skipped.add(each, err)
+			continue
+		}
+		list = append(list, analysedEntry{Entry: each, message: msg})
+		for _, name := range msg.params() {
+			if !slices.Contains(params[each.Key], name) {
+				params[each.Key] = append(params[each.Key], name)
+			}
+		}
+	}
+	for _, names := range params {
+		sort.Strings(names)
+	}
+	return list, params
+}
+
+// groupByLanguage returns the entries per language.
+func groupByLanguage(entries []analysedEntry) map[string][]analysedEntry {
+	groups := map[string][]analysedEntry{}
+	for _, each := range entries {
+		groups[each.Language] = append(groups[each.Language], each)
+	}
+	return groups
+}
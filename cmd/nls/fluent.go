@@ -0,0 +1,39 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/emicklei/nls/internal/messagefile"
+)
+
+// collectFluentEntries reads a Project Fluent (.ftl) file, see messagefile.DecodeFluent.
+// Messages that cannot be compiled are logged and skipped.
+func collectFluentEntries(language, fullName string) ([]Entry, error) {
+	if *oVerbose {
+		log.Printf("processing %s in [%s]\n", fullName, language)
+	}
+	file, err := os.Open(fullName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	messages, err := messagefile.DecodeFluent(file)
+	if err != nil {
+		log.Printf("%s: %v\n", fullName, err)
+	}
+	entries := []Entry{}
+	for _, each := range messages {
+		entries = append(entries, Entry{
+			Language:    language,
+			Key:         each.Key,
+			Text:        each.Text,
+			Description: each.Description,
+			File:        fullName,
+		})
+	}
+	if *oVerbose {
+		log.Printf("%d messages found\n", len(entries))
+	}
+	return entries, nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCollectFluentEntries(t *testing.T) {
+	source := `### Resource comment
+
+-brand = Acme
+
+# a friendly greeting
+hello = Hello { $name }, welcome to { -brand }!
+emails =
+    { $count ->
+        [one] You have one email.
+       *[other] You have { $count } emails.
+    }
+login = Login
+    .placeholder = email@{ -brand }.com
+multi =
+    first line
+    second line
+`
+	fileName := filepath.Join(t.TempDir(), "messages.ftl")
+	if err := os.WriteFile(fileName, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := collectFluentEntries("en", fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"hello":             "Hello {{.name}}, welcome to Acme!",
+		"emails":            "{{if eq .count 1}}You have one email.{{else}}You have {{.count}} emails.{{end}}",
+		"login":             "Login",
+		"login.placeholder": "email@Acme.com",
+		"multi":             "first line\nsecond line",
+	}
+	if got, want := len(entries), len(want); got != want {
+		t.Fatalf("got [%d] want [%d] entries: %v", got, want, entries)
+	}
+	for _, each := range entries {
+		if got, want := each.Text, want[each.Key]; got != want {
+			t.Errorf("%s: got [%s] want [%s]", each.Key, got, want)
+		}
+	}
+	if got, want := entries[0].Description, "a friendly greeting"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+}
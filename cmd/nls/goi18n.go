@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+var oPluralParam = new(string)
+
+// goi18nReserved are the keys of a go-i18n message; a table without them holds nested messages.
+var goi18nReserved = []string{"id", "description", "hash", "leftdelim", "rightdelim", "zero", "one", "two", "few", "many", "other"}
+
+// importGoI18n reads go-i18n (github.com/nicksnyder/go-i18n) message files such as active.nl.toml,
+// or all .toml and .json message files in a directory. The language is taken from the file name,
+// e.g. nl for active.nl.toml or nl.json.
+// Plural forms one and other become {{if eq .PluralCount 1}}one{{else}}other{{end}}.
+func importGoI18n(in string, existing []Entry) ([]Entry, error) {
+	files := []string{in}
+	if info, err := os.Stat(in); err != nil {
+		return nil, err
+	} else if info.IsDir() {
+		files = nil
+		for _, pattern := range []string{"*.toml", "*.json"} {
+			matches, err := filepath.Glob(filepath.Join(in, pattern))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, matches...)
+		}
+	}
+	var entries []Entry
+	var skipped refused
+	for _, each := range files {
+		ext := filepath.Ext(each)
+		parts := strings.Split(strings.TrimSuffix(filepath.Base(each), ext), ".")
+		lang := parts[len(parts)-1]
+		if lang == "" {
+			return nil, fmt.Errorf("cannot determine language of %s, expected a name like active.nl%s", each, ext)
+		}
+		data, err := os.ReadFile(each)
+		if err != nil {
+			return nil, err
+		}
+		content := map[string]any{}
+		if ext == ".json" {
+			err = json.Unmarshal(data, &content)
+		} else {
+			err = toml.Unmarshal(data, &content)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read %s: %w", each, err)
+		}
+		collectGoI18n(lang, "", content, &entries, &skipped)
+	}
+	skipped.report("templates")
+	sortEntries(entries)
+	return entries, nil
+}
+
+func collectGoI18n(lang, prefix string, content map[string]any, entries *[]Entry, skipped *refused) {
+	for id, value := range content {
+		key := prefix + id
+		switch v := value.(type) {
+		case string:
+			*entries = append(*entries, Entry{Language: lang, Key: key, Text: v})
+		case map[string]any:
+			if !isGoI18nMessage(v) {
+				collectGoI18n(lang, key+".", v, entries, skipped)
+				continue
+			}
+			entry := Entry{Language: lang, Key: key}
+			if s, ok := v["id"].(string); ok {
+				entry.Key = s
+			}
+			entry.Description, _ = v["description"].(string)
+			text, err := goi18nText(v)
+			if err != nil {
+				skipped.add(entry, err)
+				continue
+			}
+			entry.Text = text
+			*entries = append(*entries, entry)
+		}
+	}
+}
+
+func isGoI18nMessage(table map[string]any) bool {
+	for key := range table {
+		for _, each := range goi18nReserved {
+			if strings.EqualFold(key, each) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// goi18nText returns the template of a message with plural forms.
+func goi18nText(message map[string]any) (string, error) {
+	forms := map[string]string{}
+	for key, value := range message {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		switch key = strings.ToLower(key); key {
+		case "leftdelim", "rightdelim":
+			if s != "{{" && s != "}}" {
+				return "", fmt.Errorf("unsupported template delimiter %q", s)
+			}
+		case "zero", "one", "two", "few", "many", "other":
+			forms[key] = s
+		}
+	}
+	unsupported := []string{}
+	for key := range forms {
+		if key != "one" && key != "other" {
+			unsupported = append(unsupported, key)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return "", fmt.Errorf("unsupported plural forms %v", unsupported)
+	}
+	other, ok := forms["other"]
+	if !ok {
+		return "", fmt.Errorf("message has no other form")
+	}
+	one, ok := forms["one"]
+	if !ok || one == other {
+		return other, nil
+	}
+	return fmt.Sprintf("{{if eq .%s 1}}%s{{else}}%s{{end}}", *oPluralParam, one, other), nil
+}
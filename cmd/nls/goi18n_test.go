@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGoI18nText(t *testing.T) {
+	defer func(param string) { *oPluralParam = param }(*oPluralParam)
+	*oPluralParam = "PluralCount"
+	got, err := goi18nText(map[string]any{"description": "cats", "one": "{{.Count}} cat", "other": "{{.Count}} cats"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "{{if eq .PluralCount 1}}{{.Count}} cat{{else}}{{.Count}} cats{{end}}"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if _, err := goi18nText(map[string]any{"few": "x", "other": "y"}); err == nil {
+		t.Error("expected error for unsupported plural form")
+	}
+	if _, err := goi18nText(map[string]any{"leftDelim": "<<", "other": "y"}); err == nil {
+		t.Error("expected error for unsupported delimiter")
+	}
+}
+
+func TestImportGoI18nLanguage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"active.nl.toml": "hello = \"hallo\"\n",
+		"en.json":        `{"hello": "hello"}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := importGoI18n(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	languages := map[string]string{}
+	for _, each := range entries {
+		languages[each.Language] = each.Text
+	}
+	if got, want := len(languages), 2; got != want {
+		t.Fatalf("got [%d] want [%d]", got, want)
+	}
+	if got, want := languages["nl"]+","+languages["en"], "hallo,hello"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// goTextStatements returns per language the Go statements that add the messages to a golang.org/x/text/message
+// catalog.Builder named MessageCatalog. Placeholders become positional arguments in sorted order of their names;
+// plurals on a count use plural.Selectf. Messages with other template logic are skipped.
+func goTextStatements(entries []Entry) map[string][]string {
+	var skipped refused
+	list, params := analyseEntries(entries, &skipped)
+	if *oVerbose {
+		skipped.report("golang.org/x/text/message")
+	}
+	statements := map[string][]string{}
+	count := 0
+	for _, each := range list {
+		names := params[each.Key]
+		countParam := ""
+		if each.Plural != nil {
+			countParam = each.Plural.Param
+		}
+		arg := func(name string) string {
+			verb := "v"
+			if name == countParam {
+				verb = "d"
+			}
+			return fmt.Sprintf("%%[%d]%s", slices.Index(names, name)+1, verb)
+		}
+		tag := fmt.Sprintf("language.MustParse(%q)", each.Language)
+		count++
+		if each.Plural == nil {
+			statements[each.Language] = append(statements[each.Language], fmt.Sprintf("MessageCatalog.SetString(%s, %q, %s)",
+				tag, each.Key, strconv.Quote(printfText(each.Segments, arg))))
+			continue
+		}
+		statements[each.Language] = append(statements[each.Language], fmt.Sprintf("MessageCatalog.Set(%s, %q, plural.Selectf(%d, \"\", \"=1\", %s, \"other\", %s))",
+			tag, each.Key, slices.Index(names, countParam)+1,
+			strconv.Quote(printfText(each.Plural.One, arg)),
+			strconv.Quote(printfText(each.Plural.Other, arg))))
+	}
+	if *oVerbose {
+		log.Printf("%d messages added to the golang.org/x/text/message catalog\n", count)
+	}
+	return statements
+}
+
+// printfText returns the segments as a printf format with placeholders written by arg.
+func printfText(segments []segment, arg func(string) string) string {
+	escaped := []segment{}
+	for _, each := range segments {
+		if each.Param == "" {
+			each.Text = strings.ReplaceAll(each.Text, "%", "%%")
+		}
+		escaped = append(escaped, each)
+	}
+	return formatSegments(escaped, arg)
+}
+
+// usesPlural returns whether one of the statements uses the plural package.
+func usesPlural(statements []string) bool {
+	for _, each := range statements {
+		if strings.Contains(each, "plural.Selectf") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGoTextStatements(t *testing.T) {
+	statements := goTextStatements([]Entry{
+		{Language: "en", Key: "hello", Text: "Hello {{.name}}, 100% {{.city}}"},
+		{Language: "nl", Key: "cats", Text: "{{.owner}} heeft {{if eq .n 1}}een kat{{else}}{{.n}} katten{{end}}"},
+		{Language: "nl", Key: "logic", Text: "{{range .items}}x{{end}}"},
+	})
+	want := `MessageCatalog.SetString(language.MustParse("en"), "hello", "Hello %[2]v, 100%% %[1]v")`
+	if got := strings.Join(statements["en"], "\n"); got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	want = `MessageCatalog.Set(language.MustParse("nl"), "cats", plural.Selectf(1, "", "=1", "%[2]v heeft een kat", "other", "%[2]v heeft %[1]d katten"))`
+	if got := strings.Join(statements["nl"], "\n"); got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if !usesPlural(statements["nl"]) || usesPlural(statements["en"]) {
+		t.Error("only nl uses plural")
+	}
+}
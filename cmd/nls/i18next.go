@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// exportI18next writes a JSON bundle per language, e.g. locales/nl/translation.json,
+// that is compatible with i18next v4. Placeholders {{.name}} are written as {{name}}.
+// Plural messages are written as <key>_one and <key>_other with {{count}} as the count placeholder.
+func exportI18next(entries []Entry, out string) error {
+	bundles := map[string]map[string]any{}
+	var skipped refused
+	for _, each := range entries {
+		if each.Text == "" {
+			continue
+		}
+		m, err := analyseMessage(each.Text)
+		if err != nil {
+			skipped.add(each, err)
+			continue
+		}
+		bundle, ok := bundles[each.Language]
+		if !ok {
+			bundle = map[string]any{}
+			bundles[each.Language] = bundle
+		}
+		texts := map[string]string{}
+		if m.Plural == nil {
+			texts[each.Key] = formatSegments(m.Segments, i18nextParam(""))
+		} else {
+			texts[each.Key+"_one"] = formatSegments(m.Plural.One, i18nextParam(m.Plural.Param))
+			texts[each.Key+"_other"] = formatSegments(m.Plural.Other, i18nextParam(m.Plural.Param))
+		}
+		for key, text := range texts {
+			if *oNested {
+				if err := putNested(bundle, strings.Split(key, "."), text); err != nil {
+					skipped.add(each, err)
+				}
+			} else {
+				bundle[key] = text
+			}
+		}
+	}
+	skipped.report("i18next")
+	for lang, bundle := range bundles {
+		data, err := json.MarshalIndent(bundle, "", "  ")
+		if err != nil {
+			return err
+		}
+		fileName := filepath.Join(out, lang, "translation.json")
+		if *oVerbose {
+			log.Printf("writing %s\n", fileName)
+		}
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fileName, append(data, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// i18nextParam returns a function that writes a placeholder as {{name}} and the count parameter as {{count}}.
+func i18nextParam(countParam string) func(name string) string {
+	return func(name string) string {
+		if name == countParam {
+			name = "count"
+		}
+		return "{{" + name + "}}"
+	}
+}
+
+// putNested stores the text in nested objects, one for each part of the key except the last.
+func putNested(bundle map[string]any, path []string, text string) error {
+	for i, part := range path[:len(path)-1] {
+		child, ok := bundle[part]
+		if !ok {
+			child = map[string]any{}
+			bundle[part] = child
+		}
+		object, ok := child.(map[string]any)
+		if !ok {
+			return fmt.Errorf("key %q is both a message and a parent", strings.Join(path[:i+1], "."))
+		}
+		bundle = object
+	}
+	last := path[len(path)-1]
+	if _, ok := bundle[last].(map[string]any); ok {
+		return fmt.Errorf("key %q is both a message and a parent", strings.Join(path, "."))
+	}
+	bundle[last] = text
+	return nil
+}
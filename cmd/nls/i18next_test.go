@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportI18next(t *testing.T) {
+	out := t.TempDir()
+	entries := []Entry{
+		{Language: "en", Key: "hello", Text: "Hello {{.name}}"},
+		{Language: "en", Key: "cats", Text: "{{.n}} {{if eq .n 1}}cat{{else}}cats{{end}} of {{.name}}"},
+		{Language: "en", Key: "dogs", Text: "{{if gt .n 1}}{{.n}} dogs{{else}}one dog{{end}}"},
+	}
+	if err := exportI18next(entries, out); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(out, "en", "translation.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+  "cats_one": "{{count}} cat of {{name}}",
+  "cats_other": "{{count}} cats of {{name}}",
+  "dogs_one": "one dog",
+  "dogs_other": "{{count}} dogs",
+  "hello": "Hello {{name}}"
+}
+`
+	if got := string(data); got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+}
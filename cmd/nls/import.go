@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+)
+
+// importer reads the entries of all languages from a file or directory in a source format.
+// The existing entries are those read from the language directories before the import.
+type importer func(in string, existing []Entry) ([]Entry, error)
+
+var importers = map[string]importer{
+	"arb":     importARB,
+	"csv":     importCSV,
+	"go-i18n": importGoI18n,
+	"tmx":     importTMX,
+}
+
+// nls import -dir messages -format arb -in lib/l10n
+func importCommand(args []string) error {
+	set := flag.NewFlagSet("import", flag.ExitOnError)
+	set.StringVar(oDir, "dir", "", "directory with the language directories to update")
+	set.BoolVar(oVerbose, "v", false, "verbose output")
+	format := set.String("format", "arb", "source format")
+	set.StringVar(format, "from", "arb", "source format, same as -format")
+	set.StringVar(oSource, "source", "en", "tmx: source language")
+	set.StringVar(oPluralParam, "plural-param", "PluralCount", "go-i18n: name of the count parameter of plural messages")
+	in := set.String("in", "", "file or directory to import")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+	read, ok := importers[*format]
+	if !ok {
+		return fmt.Errorf("unknown import format %q", *format)
+	}
+	if *in == "" {
+		return errors.New("missing -in file or directory")
+	}
+	existing, err := loadEntries(*oDir)
+	if err != nil {
+		return err
+	}
+	imported, err := read(*in, existing)
+	if err != nil {
+		return err
+	}
+	merged := fillMissingEntries(mergeEntries(existing, imported))
+	return writeEntries(merged, *oDir)
+}
+
+// mergeEntries updates existing entries with the non-empty text, description and comment of imported ones,
+// or all of them if the imported entry replaces the existing one, and adds the imported entries that do not exist yet.
+func mergeEntries(existing, imported []Entry) []Entry {
+	index := map[string]int{}
+	for i, each := range existing {
+		index[each.Language+"."+each.Key] = i
+	}
+	updated, added := 0, 0
+	for _, each := range imported {
+		i, ok := index[each.Language+"."+each.Key]
+		if !ok {
+			index[each.Language+"."+each.Key] = len(existing)
+			existing = append(existing, each)
+			added++
+			continue
+		}
+		target := &existing[i]
+		if (each.Text != "" || each.Replace) && !equivalentTexts(each.Text, target.Text) {
+			target.Text = each.Text
+			target.State = each.State
+			target.SourceHash = each.SourceHash
+			target.OldSourceHash = each.OldSourceHash
+			updated++
+		} else if each.State != "" {
+			target.State = each.State
+		}
+		if each.Description != "" || each.Replace {
+			target.Description = each.Description
+		}
+		if each.Comment != "" || each.Replace {
+			target.Comment = each.Comment
+		}
+	}
+	if *oVerbose {
+		log.Printf("%d messages updated, %d messages added\n", updated, added)
+	}
+	return existing
+}
+
+// equivalentTexts returns whether both texts are equal or express the same message
+// such that an import does not rewrite a message that was exported unchanged.
+func equivalentTexts(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ma, err := analyseMessage(a)
+	if err != nil {
+		return false
+	}
+	mb, err := analyseMessage(b)
+	if err != nil {
+		return false
+	}
+	return icuText(ma) == icuText(mb)
+}
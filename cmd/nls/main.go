@@ -10,45 +10,56 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"text/template"
 	"unicode"
 
-	"gopkg.in/yaml.v3"
+	"github.com/emicklei/nls/internal/messagefile"
 )
 
 var (
-	oDir     = flag.String("dir", "", "directory to scan for .yaml files")
-	oPkg     = flag.String("pkg", "nls", "package name for the generated code")
-	oVerbose = flag.Bool("v", false, "verbose output")
+	oDir             = flag.String("dir", "", "directory to scan for .yaml files")
+	oPkg             = flag.String("pkg", "nls", "package name for the generated code")
+	oVerbose         = flag.Bool("v", false, "verbose output")
+	oGoText          = flag.Bool("gotext", false, "also generate a golang.org/x/text/message catalog")
+	oLazy            = flag.Bool("lazy", false, "parse templates on first use instead of at program start")
+	oSplit           = flag.Bool("split", false, "generate a file per language with build tag nls_<lang>")
+	oDefault         = flag.String("default", "en", "comma separated languages that are always compiled in when split")
+	oEmbed           = flag.Bool("embed", false, "embed the message files instead of generating the messages")
+	oSource          = flag.String("source", "", "source language whose messages are approved unless their state is set")
+	oPseudo          = flag.String("pseudo", "", "pseudo language, e.g. en-XA, to generate from the -source language (default en)")
+	oPseudoExpansion = flag.Float64("pseudo-expansion", 0.35, "fraction of characters added to the texts of the pseudo language")
+	oMinState        = flag.String("min-state", "new", "minimum state (new, needs-review, translated, approved) of the generated messages")
 )
 
 // go run . -v -dir ../../example/messages -pkg ../../example/nls
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
 	flag.Parse()
-	langDirs, err := os.ReadDir(*oDir)
+	allEntries, err := loadEntries(*oDir)
 	if err != nil {
 		log.Fatal(err)
 	}
-	allEntries := []Entry{}
-	for _, each := range langDirs {
-		if each.IsDir() {
-			messageFiles, err := os.ReadDir(filepath.Join(*oDir, each.Name()))
-			if err != nil {
-				log.Printf("cannot read directory %s\n", each.Name())
-			}
-			for _, file := range messageFiles {
-				if filepath.Ext(file.Name()) == ".yaml" {
-					fullName := filepath.Join(*oDir, each.Name(), file.Name())
-					if entries, err := collectEntries(each.Name(), fullName); err != nil {
-						log.Printf("cannot process file %s:%v\n", err, fullName)
-					} else {
-						allEntries = append(allEntries, entries...)
-					}
-				}
-			}
+	allEntries = fillMissingEntries(allEntries)
+	if *oSource != "" {
+		sources, err := readSources(*oDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		allEntries = trackSources(allEntries, sources)
+		if err := writeSources(*oDir, allEntries, sources); err != nil {
+			log.Fatal(err)
 		}
 	}
-	allEntries = fillMissingEntries(allEntries)
 	if *oVerbose {
 		for _, each := range allEntries {
 			log.Printf("%s.%s=%s\n", each.Language, each.Key, each.Text)
@@ -57,7 +68,20 @@ func main() {
 	if err := os.Mkdir(*oPkg, os.ModePerm); err != nil && !errors.Is(err, fs.ErrExist) {
 		log.Fatalf("%[1]T %[1]v", err)
 	}
-	if err := writeGoFile(allEntries); err != nil {
+	goEntries := allEntries
+	if *oPseudo != "" {
+		source := *oSource
+		if source == "" {
+			source = "en"
+		}
+		pseudoLang, err := pseudoEntries(allEntries, *oPseudo, source)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// the pseudo language is not written to the message files
+		goEntries = append(slices.Clone(allEntries), pseudoLang...)
+	}
+	if err := writeGoFile(goEntries); err != nil {
 		log.Fatal(err)
 	}
 	if err := writeEntries(allEntries, *oDir); err != nil {
@@ -65,12 +89,61 @@ func main() {
 	}
 }
 
+// commands are the subcommands of the tool; without one, the Go package is generated.
+var commands = map[string]func(args []string) error{
+	"export":    exportCommand,
+	"import":    importCommand,
+	"overrides": overridesCommand,
+	"stale":     staleCommand,
+	"stats":     statsCommand,
+}
+
+// loadEntries collects the entries of all message files found in the language directories of dir.
+func loadEntries(dir string) ([]Entry, error) {
+	langDirs, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	allEntries := []Entry{}
+	for _, each := range langDirs {
+		if each.IsDir() {
+			messageFiles, err := os.ReadDir(filepath.Join(dir, each.Name()))
+			if err != nil {
+				log.Printf("cannot read directory %s\n", each.Name())
+			}
+			for _, file := range messageFiles {
+				fullName := filepath.Join(dir, each.Name(), file.Name())
+				var entries []Entry
+				switch filepath.Ext(file.Name()) {
+				case ".yaml":
+					entries, err = collectEntries(each.Name(), fullName)
+				case ".ftl":
+					entries, err = collectFluentEntries(each.Name(), fullName)
+				default:
+					continue
+				}
+				if err != nil {
+					log.Printf("cannot process file %s:%v\n", err, fullName)
+				} else {
+					allEntries = append(allEntries, entries...)
+				}
+			}
+		}
+	}
+	return allEntries, nil
+}
+
 //go:embed localizer.template
 var localizerTemplate string
 
-// constantName returns the full constant name, e.g. M_hello, M_cats1, M_trends2_3
+// constantName returns the full constant name, e.g. M_hello, M_cats1, M_trends2_3, M_login_placeholder
 func constantName(e Entry) string {
-	name := "M_" + e.Key
+	name := "M_" + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, e.Key)
 	// count replacements
 	replacements := e.Replacements()
 	if replacements == 0 {
@@ -84,22 +157,82 @@ func constantName(e Entry) string {
 	return fmt.Sprintf("%s%d", name, replacements)
 }
 
-func writeGoFile(entries []Entry) error {
-	outName := filepath.Join(*oPkg, "generated_catalog.go")
-	if *oVerbose {
-		log.Printf("writing %s\n", outName)
-	}
-	out, err := os.Create(outName)
+// staticText returns the text of a message that has no template actions.
+func staticText(text string) (string, bool) {
+	segments, err := simpleSegments(text)
 	if err != nil {
-		return err
+		return "", false
 	}
-	defer out.Close()
-	tmpl, err := template.New("localizer").Funcs(template.FuncMap{
-		"constantName": constantName,
-	}).Parse(localizerTemplate)
-	if err != nil {
+	for _, each := range segments {
+		if each.Param != "" {
+			return "", false
+		}
+	}
+	return formatSegments(segments, nil), true
+}
+
+// validateTemplates returns an error for each message that cannot be parsed as a template.
+func validateTemplates(entries []Entry) error {
+	errs := []error{}
+	for _, each := range entries {
+		if _, err := template.New(each.Key).Parse(each.Text); err != nil {
+			errs = append(errs, fmt.Errorf("%s.%s: %w", each.Language, each.Key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+//go:embed language.template
+var languageTemplate string
+
+// goFile is the data for generating a Go file.
+type goFile struct {
+	Package       string
+	Keys          []string
+	UniqueEntries map[string]Entry
+	Entries       []Entry
+	LanguageTags  []string
+	WithGoText    bool
+	GoText        []string // statements for the entries
+	Lazy          bool
+	Split         bool
+	Language      string // of a split file
+	BuildTag      string // of a split file, empty if always compiled in
+	Embed         bool
+	EmbedDir      string // relative to the package directory
+	Completeness  []languageStats
+	WatchError    string // why Watch cannot reload the messages, empty if it can
+}
+
+func writeGoFile(entries []Entry) error {
+	if err := validateTemplates(entries); err != nil {
 		return err
 	}
+	if messagefile.Progress(*oMinState) < 0 {
+		return fmt.Errorf("unknown -min-state %q", *oMinState)
+	}
+	embedded := "" // directory of the message files in the package
+	if *oEmbed {
+		if *oMinState != messagefile.StateNew {
+			return errors.New("-embed cannot be combined with -min-state")
+		}
+		if *oPseudo != "" {
+			return errors.New("-embed cannot be combined with -pseudo")
+		}
+		if *oSplit {
+			return errors.New("-embed cannot be combined with -split")
+		}
+		for _, each := range entries {
+			if filepath.Ext(each.File) == ".ftl" {
+				return fmt.Errorf("-embed does not support Fluent files such as %s", each.File)
+			}
+		}
+		dir, err := embedDir()
+		if err != nil {
+			return err
+		}
+		embedded = dir
+	}
 	uniqueEntries := map[string]Entry{}
 	languages := []string{}
 	// collect unique entries
@@ -118,18 +251,112 @@ func writeGoFile(entries []Entry) error {
 			}
 		}
 	}
-	data := struct {
-		Package       string
-		UniqueEntries map[string]Entry
-		Entries       []Entry
-		LanguageTags  []string
-	}{
+	// message IDs are the indexes of the sorted keys
+	keys := []string{}
+	for key := range uniqueEntries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// only entries with at least the minimum state are registered
+	registered := []Entry{}
+	for _, each := range entries {
+		if each.isRegistered() {
+			registered = append(registered, each)
+		}
+	}
+	tmpl, err := template.New("localizer").Funcs(template.FuncMap{
+		"constantName": constantName,
+		"messageID": func(key string) int {
+			return sort.SearchStrings(keys, key)
+		},
+		"isStatic": func(text string) bool {
+			_, ok := staticText(text)
+			return ok
+		},
+		"staticText": func(text string) string {
+			s, _ := staticText(text)
+			return strconv.Quote(s)
+		},
+		"usesPlural": usesPlural,
+	}).Parse(localizerTemplate)
+	if err != nil {
+		return err
+	}
+	if _, err := tmpl.New("language").Parse(languageTemplate); err != nil {
+		return err
+	}
+	data := goFile{
 		Package:       filepath.Base(*oPkg),
+		Keys:          keys,
 		UniqueEntries: uniqueEntries,
-		Entries:       entries,
+		Entries:       registered,
 		LanguageTags:  languages,
+		WithGoText:    *oGoText,
+		Lazy:          *oLazy,
+		Split:         *oSplit,
+		Embed:         *oEmbed,
+		EmbedDir:      embedded,
+		Completeness:  translationStats(entries),
 	}
-	return tmpl.Execute(out, data)
+	// the message files are reloaded without the pseudo language and without filtering by state
+	if *oPseudo != "" {
+		data.WatchError = "Watch cannot reload the messages of a package generated with -pseudo"
+	} else if *oMinState != messagefile.StateNew {
+		data.WatchError = "Watch cannot reload the messages of a package generated with -min-state"
+	}
+	goText := map[string][]string{}
+	if *oGoText {
+		goText = goTextStatements(registered)
+	}
+	// remove the language files of a previous split
+	previous, _ := filepath.Glob(filepath.Join(*oPkg, "generated_catalog_*_messages.go"))
+	for _, each := range previous {
+		if err := os.Remove(each); err != nil {
+			return err
+		}
+	}
+	if !*oSplit {
+		for _, lang := range languages {
+			data.GoText = append(data.GoText, goText[lang]...)
+		}
+		return executeGoFile(tmpl, "localizer", "generated_catalog.go", data)
+	}
+	if err := executeGoFile(tmpl, "localizer", "generated_catalog.go", data); err != nil {
+		return err
+	}
+	defaults := strings.Split(*oDefault, ",")
+	for _, lang := range languages {
+		langData := data
+		langData.Language = lang
+		langData.GoText = goText[lang]
+		langData.Entries = []Entry{}
+		for _, each := range registered {
+			if each.Language == lang {
+				langData.Entries = append(langData.Entries, each)
+			}
+		}
+		suffix := strings.ToLower(strings.ReplaceAll(lang, "-", "_"))
+		if !slices.Contains(defaults, lang) {
+			langData.BuildTag = "nls_" + suffix
+		}
+		if err := executeGoFile(tmpl, "language", "generated_catalog_"+suffix+"_messages.go", langData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func executeGoFile(tmpl *template.Template, name, fileName string, data goFile) error {
+	outName := filepath.Join(*oPkg, fileName)
+	if *oVerbose {
+		log.Printf("writing %s\n", outName)
+	}
+	out, err := os.Create(outName)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return tmpl.ExecuteTemplate(out, name, data)
 }
 
 func collectEntries(language, fullName string) ([]Entry, error) {
@@ -141,40 +368,26 @@ func collectEntries(language, fullName string) ([]Entry, error) {
 		return nil, err
 	}
 	defer reader.Close()
-	dec := yaml.NewDecoder(reader)
-	var node yaml.Node
-	err = dec.Decode(&node)
+	messages, err := messagefile.Decode(reader)
 	if err != nil {
 		return nil, err
 	}
 	if *oVerbose {
-		log.Printf("%d messages found\n", len(node.Content))
+		log.Printf("%d messages found\n", len(messages))
 	}
 	var entries []Entry
-	for i, content := range node.Content[0].Content {
-		// is key?
-		if i%2 == 0 {
-			keyNode := content
-			valueNode := node.Content[0].Content[i+1]
-			if valueNode.Tag == "!!str" {
-				entries = append(entries, Entry{Language: language, Key: keyNode.Value, Text: valueNode.Value, Comment: keyNode.HeadComment})
-			} else if valueNode.Tag == "!!map" {
-				entry := Entry{Language: language, Key: keyNode.Value, Comment: keyNode.HeadComment}
-				for j, each := range valueNode.Content {
-					if j%2 == 0 {
-						mapkeyNode := each
-						mapvalueNode := valueNode.Content[j+1]
-						if mapkeyNode.Value == "msg" {
-							entry.Text = mapvalueNode.Value
-						}
-						if mapkeyNode.Value == "desc" {
-							entry.Description = mapvalueNode.Value
-						}
-					}
-				}
-				entries = append(entries, entry)
-			}
-		}
+	for _, each := range messages {
+		entries = append(entries, Entry{
+			Language:      language,
+			Key:           each.Key,
+			Text:          each.Text,
+			Description:   each.Description,
+			Comment:       each.Comment,
+			File:          fullName,
+			State:         each.State,
+			SourceHash:    each.SourceHash,
+			OldSourceHash: each.OldSourceHash,
+		})
 	}
 	return entries, nil
 }
@@ -194,6 +407,10 @@ func fillMissingEntries(allEntries []Entry) []Entry {
 		}
 	}
 	for lang, entries := range entriesPerLanguage {
+		if isRegionalDelta(lang, entriesPerLanguage) {
+			// only has the keys that differ from its base language
+			continue
+		}
 		// key is message key
 		keysInLang := map[string]bool{}
 		for _, each := range entries {
@@ -217,3 +434,14 @@ func fillMissingEntries(allEntries []Entry) []Entry {
 	}
 	return allEntries
 }
+
+// isRegionalDelta returns whether a language, such as en-GB, has a base language, such as en, with entries.
+func isRegionalDelta(lang string, entriesPerLanguage map[string][]Entry) bool {
+	for i := strings.LastIndex(lang, "-"); i > 0; i = strings.LastIndex(lang, "-") {
+		lang = lang[:i]
+		if _, ok := entriesPerLanguage[lang]; ok {
+			return true
+		}
+	}
+	return false
+}
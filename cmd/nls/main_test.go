@@ -0,0 +1,205 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/nls/internal/messagefile"
+)
+
+func TestFillMissingEntriesRegional(t *testing.T) {
+	entries := []Entry{
+		{Language: "en", Key: "color", Text: "color"},
+		{Language: "en", Key: "hello", Text: "hello"},
+		{Language: "en-GB", Key: "color", Text: "colour"},
+		{Language: "pt-BR", Key: "hello", Text: "olá"},
+	}
+	filled := fillMissingEntries(entries)
+	keys := map[string]bool{}
+	for _, each := range filled {
+		keys[each.Language+"."+each.Key] = true
+	}
+	if keys["en-GB.hello"] {
+		t.Error("unexpected stub in regional delta en-GB")
+	}
+	// pt-BR has no base language so it is complete
+	if !keys["pt-BR.color"] {
+		t.Error("missing stub in pt-BR")
+	}
+	if got, want := len(filled), 5; got != want {
+		t.Errorf("got [%d] want [%d]", got, want)
+	}
+}
+
+func TestRegisteredEntries(t *testing.T) {
+	defer func(source, minState string) { *oSource, *oMinState = source, minState }(*oSource, *oMinState)
+	*oSource = "en"
+	entries := []Entry{
+		{Language: "en", Key: "hello", Text: "hello"},
+		{Language: "nl", Key: "hello", Text: "hallo", State: messagefile.StateNeedsReview},
+		{Language: "de", Key: "hello", Text: "hallo"},
+		{Language: "de", Key: "suffix", Text: "", State: messagefile.StateTranslated},
+		{Language: "nl", Key: "suffix", Text: ""},
+	}
+	for _, each := range []struct {
+		minState string
+		want     string
+	}{
+		{messagefile.StateNew, "en.hello nl.hello de.hello de.suffix"},
+		{messagefile.StateTranslated, "en.hello de.hello de.suffix"},
+		{messagefile.StateApproved, "en.hello"},
+	} {
+		*oMinState = each.minState
+		registered := []string{}
+		for _, entry := range entries {
+			if entry.isRegistered() {
+				registered = append(registered, entry.Language+"."+entry.Key)
+			}
+		}
+		if got := strings.Join(registered, " "); got != each.want {
+			t.Errorf("%s: got [%s] want [%s]", each.minState, got, each.want)
+		}
+	}
+}
+
+func TestWriteEntriesState(t *testing.T) {
+	dir := t.TempDir()
+	entries := []Entry{
+		{Language: "nl", Key: "hello", Text: "hallo", State: messagefile.StateNeedsReview},
+		{Language: "nl", Key: "suffix", Text: "", Description: "after the name", State: messagefile.StateApproved},
+		{Language: "nl", Key: "world", Text: "wereld"},
+	}
+	if err := writeEntries(entries, dir); err != nil {
+		t.Fatal(err)
+	}
+	read, err := collectEntries("nl", filepath.Join(dir, "nl", "messages.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, each := range read {
+		each.File = ""
+		if each != entries[i] {
+			t.Errorf("got [%v] want [%v]", each, entries[i])
+		}
+	}
+}
+
+func TestWriteEntriesQuotes(t *testing.T) {
+	dir := t.TempDir()
+	entries := []Entry{
+		{Language: "en", Key: "a", Text: "Don't {count}"},
+		{Language: "en", Key: "b", Text: "'quoted'"},
+		{Language: "en", Key: "c", Text: "Note: this"},
+		{Language: "en", Key: "d", Text: "true"},
+		{Language: "en", Key: "e", Text: "it's #1", Description: "don't"},
+		{Language: "en", Key: "f", Text: "Don't"},
+	}
+	if err := writeEntries(entries, dir); err != nil {
+		t.Fatal(err)
+	}
+	read, err := collectEntries("en", filepath.Join(dir, "en", "messages.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(read), len(entries); got != want {
+		t.Fatalf("got [%d] want [%d]", got, want)
+	}
+	for i, each := range read {
+		if each.Text != entries[i].Text || each.Description != entries[i].Description {
+			t.Errorf("got [%v] want [%v]", each, entries[i])
+		}
+	}
+}
+
+func TestWriteGoFileSplit(t *testing.T) {
+	defer func(pkg string, split bool, defaults string) {
+		*oPkg, *oSplit, *oDefault = pkg, split, defaults
+	}(*oPkg, *oSplit, *oDefault)
+	*oPkg, *oSplit, *oDefault = t.TempDir(), true, "en"
+	entries := []Entry{
+		{Language: "en", Key: "hello", Text: "hello"},
+		{Language: "nl", Key: "hello", Text: "hallo"},
+		{Language: "pt-BR", Key: "hello", Text: "olá"},
+	}
+	if err := writeGoFile(entries); err != nil {
+		t.Fatal(err)
+	}
+	files, err := filepath.Glob(filepath.Join(*oPkg, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, each := range files {
+		names = append(names, filepath.Base(each))
+	}
+	want := "generated_catalog.go,generated_catalog_en_messages.go,generated_catalog_nl_messages.go,generated_catalog_pt_br_messages.go"
+	if got := strings.Join(names, ","); got != want {
+		t.Fatalf("got [%s] want [%s]", got, want)
+	}
+	for file, constraint := range map[string]string{
+		"generated_catalog_en_messages.go":    "",
+		"generated_catalog_nl_messages.go":    "//go:build !nls_only || nls_nl\n",
+		"generated_catalog_pt_br_messages.go": "//go:build !nls_only || nls_pt_br\n",
+	} {
+		data, err := os.ReadFile(filepath.Join(*oPkg, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		content := string(data)
+		if constraint == "" && strings.Contains(content, "//go:build") {
+			t.Errorf("%s: unexpected build constraint", file)
+		}
+		if !strings.Contains(content, constraint) {
+			t.Errorf("%s: missing build constraint %q", file, constraint)
+		}
+		if !strings.Contains(content, "Languages = append(Languages, language.MustParse(") {
+			t.Errorf("%s: language is not appended to Languages", file)
+		}
+	}
+	// without split, the language files are removed
+	*oSplit = false
+	if err := writeGoFile(entries); err != nil {
+		t.Fatal(err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(*oPkg, "*.go")); len(files) != 1 {
+		t.Errorf("got %v want generated_catalog.go only", files)
+	}
+}
+
+func TestEmbedDir(t *testing.T) {
+	defer func(pkg, dir string) { *oPkg, *oDir = pkg, dir }(*oPkg, *oDir)
+	*oPkg, *oDir = "nls", "nls/messages"
+	if got, err := embedDir(); err != nil || got != "messages" {
+		t.Errorf("got [%s,%v] want [messages,<nil>]", got, err)
+	}
+	for _, dir := range []string{"messages", "nls", "."} {
+		*oDir = dir
+		if _, err := embedDir(); err == nil {
+			t.Errorf("expected error for -dir %s", dir)
+		}
+	}
+}
+
+func TestWriteGoFileWatch(t *testing.T) {
+	defer func(pkg, minState string) { *oPkg, *oMinState = pkg, minState }(*oPkg, *oMinState)
+	*oPkg = t.TempDir()
+	entries := []Entry{{Language: "en", Key: "hello", Text: "hello"}}
+	for minState, want := range map[string]string{
+		messagefile.StateNew:        "NLS.WatchTable(",
+		messagefile.StateTranslated: `errors.New("Watch cannot reload the messages of a package generated with -min-state")`,
+	} {
+		*oMinState = minState
+		if err := writeGoFile(entries); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(filepath.Join(*oPkg, "generated_catalog.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s: missing %s", minState, want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// override is a message of an application that replaces the message of a base catalog.
+type override struct {
+	Language string
+	Key      string
+	Base     string // directory of the base catalog
+}
+
+// nls overrides -dir messages -base ../lib/messages,../defaults/messages
+func overridesCommand(args []string) error {
+	set := flag.NewFlagSet("overrides", flag.ExitOnError)
+	set.StringVar(oDir, "dir", "", "directory with the language directories of the application")
+	set.BoolVar(oVerbose, "v", false, "verbose output")
+	base := set.String("base", "", "comma separated directories with the language directories of the base catalogs, in lookup order")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+	if *base == "" {
+		return errors.New("missing -base directories")
+	}
+	app, err := loadEntries(*oDir)
+	if err != nil {
+		return err
+	}
+	bases := map[string][]Entry{}
+	dirs := strings.Split(*base, ",")
+	for _, each := range dirs {
+		entries, err := loadEntries(each)
+		if err != nil {
+			return err
+		}
+		bases[each] = entries
+	}
+	for _, each := range overriddenEntries(app, dirs, bases) {
+		fmt.Printf("%s\t%s\t%s\n", each.Language, each.Key, each.Base)
+	}
+	return nil
+}
+
+// overriddenEntries returns the non-empty application messages that replace a non-empty message
+// of the first base catalog that has one for the same language and key.
+func overriddenEntries(app []Entry, dirs []string, bases map[string][]Entry) []override {
+	overridden := []override{}
+	for _, each := range app {
+		if each.Text == "" {
+			continue
+		}
+		for _, dir := range dirs {
+			if hasText(bases[dir], each.Language, each.Key) {
+				overridden = append(overridden, override{Language: each.Language, Key: each.Key, Base: dir})
+				break
+			}
+		}
+	}
+	sort.Slice(overridden, func(i, j int) bool {
+		if overridden[i].Key != overridden[j].Key {
+			return overridden[i].Key < overridden[j].Key
+		}
+		return overridden[i].Language < overridden[j].Language
+	})
+	return overridden
+}
+
+func hasText(entries []Entry, language, key string) bool {
+	for _, each := range entries {
+		if each.Language == language && each.Key == key && each.Text != "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestOverriddenEntries(t *testing.T) {
+	app := []Entry{
+		{Language: "nl", Key: "save", Text: "Bewaren"},
+		{Language: "en", Key: "save", Text: ""},
+		{Language: "en", Key: "title", Text: "My app"},
+		{Language: "en", Key: "cancel", Text: "Stop"},
+	}
+	dirs := []string{"lib", "defaults"}
+	bases := map[string][]Entry{
+		"lib": {
+			{Language: "nl", Key: "save", Text: "Opslaan"},
+			{Language: "en", Key: "save", Text: "Save"},
+			{Language: "en", Key: "cancel", Text: ""},
+		},
+		"defaults": {
+			{Language: "en", Key: "cancel", Text: "Cancel"},
+		},
+	}
+	got := overriddenEntries(app, dirs, bases)
+	want := []override{
+		{Language: "en", Key: "cancel", Base: "defaults"},
+		{Language: "nl", Key: "save", Base: "lib"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got [%v] want [%v]", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("got [%v] want [%v]", got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// segment is either literal text or a named placeholder of a message.
+type segment struct {
+	Text  string
+	Param string // name of the placeholder, e.g. "name" for {{.name}}
+}
+
+// simpleSegments returns the segments of a message that only uses {{.name}} placeholders.
+// It returns an error describing the first template construct that cannot be expressed that way.
+func simpleSegments(text string) ([]segment, error) {
+	tmpl, err := template.New("").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.Tree == nil {
+		return nil, nil
+	}
+	return nodeSegments(tmpl.Tree.Root.Nodes)
+}
+
+func nodeSegments(nodes []parse.Node) ([]segment, error) {
+	segments := []segment{}
+	for _, each := range nodes {
+		switch node := each.(type) {
+		case *parse.TextNode:
+			segments = append(segments, segment{Text: string(node.Text)})
+		case *parse.ActionNode:
+			param, ok := fieldName(node.Pipe)
+			if !ok {
+				return nil, fmt.Errorf("unsupported action %s", node)
+			}
+			segments = append(segments, segment{Param: param})
+		default:
+			return nil, fmt.Errorf("unsupported template logic %s", node)
+		}
+	}
+	return segments, nil
+}
+
+// fieldName returns the name of a pipeline that is just a single field such as .name
+func fieldName(pipe *parse.PipeNode) (string, bool) {
+	if pipe == nil || len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 {
+		return "", false
+	}
+	args := pipe.Cmds[0].Args
+	if len(args) != 1 {
+		return "", false
+	}
+	field, ok := args[0].(*parse.FieldNode)
+	if !ok || len(field.Ident) != 1 {
+		return "", false
+	}
+	return field.Ident[0], true
+}
+
+// formatSegments returns the text with each placeholder written by the param function.
+func formatSegments(segments []segment, param func(name string) string) string {
+	b := new(strings.Builder)
+	for _, each := range segments {
+		if each.Param != "" {
+			b.WriteString(param(each.Param))
+		} else {
+			b.WriteString(each.Text)
+		}
+	}
+	return b.String()
+}
+
+// plural is a message with a form for a count of exactly one and a form for all other counts.
+type plural struct {
+	Param string
+	One   []segment
+	Other []segment
+}
+
+// message is the analysed text of an entry; either Plural is set or Segments are.
+type message struct {
+	Segments []segment
+	Plural   *plural
+}
+
+// analyseMessage returns the segments of a message or its plural forms.
+// Plural forms are recognized for a single {{if eq .count 1}}one{{else}}other{{end}}
+// or {{if gt .count 1}}other{{else}}one{{end}} with optional text and placeholders around it.
+func analyseMessage(text string) (message, error) {
+	tmpl, err := template.New("").Parse(text)
+	if err != nil {
+		return message{}, err
+	}
+	if tmpl.Tree == nil {
+		return message{}, nil
+	}
+	nodes := tmpl.Tree.Root.Nodes
+	ifAt := -1
+	for i, each := range nodes {
+		if _, ok := each.(*parse.IfNode); ok {
+			if ifAt != -1 {
+				return message{}, fmt.Errorf("unsupported template logic %s", each)
+			}
+			ifAt = i
+		}
+	}
+	if ifAt == -1 {
+		segments, err := nodeSegments(nodes)
+		return message{Segments: segments}, err
+	}
+	before, err := nodeSegments(nodes[:ifAt])
+	if err != nil {
+		return message{}, err
+	}
+	after, err := nodeSegments(nodes[ifAt+1:])
+	if err != nil {
+		return message{}, err
+	}
+	ifNode := nodes[ifAt].(*parse.IfNode)
+	param, operator, ok := countCondition(ifNode.Pipe)
+	if !ok || ifNode.ElseList == nil {
+		return message{}, fmt.Errorf("unsupported template logic %s", ifNode)
+	}
+	then, err := nodeSegments(ifNode.List.Nodes)
+	if err != nil {
+		return message{}, err
+	}
+	otherwise, err := nodeSegments(ifNode.ElseList.Nodes)
+	if err != nil {
+		return message{}, err
+	}
+	p := &plural{Param: param}
+	if operator == "eq" {
+		p.One, p.Other = then, otherwise
+	} else {
+		p.One, p.Other = otherwise, then
+	}
+	p.One = append(append(append([]segment{}, before...), p.One...), after...)
+	p.Other = append(append(append([]segment{}, before...), p.Other...), after...)
+	return message{Plural: p}, nil
+}
+
+// countCondition returns the field and operator of a condition like eq .count 1 or gt .count 1
+func countCondition(pipe *parse.PipeNode) (string, string, bool) {
+	if pipe == nil || len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 {
+		return "", "", false
+	}
+	args := pipe.Cmds[0].Args
+	if len(args) != 3 {
+		return "", "", false
+	}
+	ident, ok := args[0].(*parse.IdentifierNode)
+	if !ok || (ident.Ident != "eq" && ident.Ident != "gt") {
+		return "", "", false
+	}
+	field, ok := args[1].(*parse.FieldNode)
+	if !ok || len(field.Ident) != 1 {
+		return "", "", false
+	}
+	number, ok := args[2].(*parse.NumberNode)
+	if !ok || !number.IsInt || number.Int64 != 1 {
+		return "", "", false
+	}
+	return field.Ident[0], ident.Ident, true
+}
+
+// params returns the sorted unique placeholder names used by a message.
+func (m message) params() []string {
+	names := []string{}
+	collect := func(segments []segment) {
+		for _, each := range segments {
+			if each.Param != "" && !slices.Contains(names, each.Param) {
+				names = append(names, each.Param)
+			}
+		}
+	}
+	collect(m.Segments)
+	if m.Plural != nil {
+		if !slices.Contains(names, m.Plural.Param) {
+			names = append(names, m.Plural.Param)
+		}
+		collect(m.Plural.One)
+		collect(m.Plural.Other)
+	}
+	sort.Strings(names)
+	return names
+}
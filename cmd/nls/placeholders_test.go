@@ -0,0 +1,65 @@
+package main
+
+import "testing"
+
+func TestSimpleSegments(t *testing.T) {
+	segments, err := simpleSegments("{{.name}} zegt {{- .greeting }}!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := formatSegments(segments, func(name string) string { return "{" + name + "}" })
+	if want := "{name} zegt{greeting}!"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if _, err := simpleSegments("{{if .ok}}yes{{end}}"); err == nil {
+		t.Error("expected error for template logic")
+	}
+	if _, err := simpleSegments("{{.name | printf}}"); err == nil {
+		t.Error("expected error for pipeline")
+	}
+}
+
+func TestPutNested(t *testing.T) {
+	bundle := map[string]any{}
+	if err := putNested(bundle, []string{"menu", "open"}, "Open"); err != nil {
+		t.Fatal(err)
+	}
+	if err := putNested(bundle, []string{"menu"}, "Menu"); err == nil {
+		t.Error("expected conflict error")
+	}
+	if got := bundle["menu"].(map[string]any)["open"]; got != "Open" {
+		t.Errorf("got [%v] want [Open]", got)
+	}
+}
+
+func TestAnalyseMessagePlural(t *testing.T) {
+	msg, err := analyseMessage("{{.count}} {{- if gt .count 1}} katten{{- else}} kat{{- end}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Plural == nil {
+		t.Fatal("expected plural")
+	}
+	arg := func(name string) string { return "<" + name + ">" }
+	if got, want := formatSegments(msg.Plural.One, arg), "<count> kat"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := formatSegments(msg.Plural.Other, arg), "<count> katten"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if _, err := analyseMessage("{{if gt .count 2}}many{{else}}few{{end}}"); err == nil {
+		t.Error("expected error for unsupported condition")
+	}
+}
+
+func TestAndroidQualifier(t *testing.T) {
+	for lang, want := range map[string]string{"nl": "nl", "en-GB": "en-rGB", "sr-Latn": "b+sr+Latn"} {
+		got, err := androidQualifier(lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("got [%s] want [%s]", got, want)
+		}
+	}
+}
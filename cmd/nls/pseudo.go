@@ -0,0 +1,64 @@
+package main
+
+import (
+	"text/template"
+	"text/template/parse"
+	"unicode/utf8"
+
+	"github.com/emicklei/nls/internal/pseudo"
+)
+
+// pseudoEntries returns the entries of a pseudo language, such as en-XA, for the entries of the source language.
+// Only the text of the templates is pseudo-localized; actions and placeholders are left intact.
+// A pseudo entry has the state of its source entry such that it is filtered by -min-state the same way.
+func pseudoEntries(entries []Entry, lang, source string) ([]Entry, error) {
+	pseudoLang := []Entry{}
+	for _, each := range entries {
+		if each.Language != source || each.Text == "" {
+			continue
+		}
+		text, err := pseudoText(each.Key, each.Text)
+		if err != nil {
+			return nil, err
+		}
+		pseudoLang = append(pseudoLang, Entry{Language: lang, Key: each.Key, Text: text, Description: each.Description,
+			State: each.effectiveState()})
+	}
+	return pseudoLang, nil
+}
+
+// pseudoText returns the template source with accented text nodes, within brackets and expanded.
+func pseudoText(key, text string) (string, error) {
+	tmpl, err := template.New(key).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	size := 0
+	var accent func(node parse.Node)
+	accent = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, each := range n.Nodes {
+				accent(each)
+			}
+		case *parse.TextNode:
+			size += utf8.RuneCount(n.Text)
+			n.Text = []byte(pseudo.Accent(string(n.Text)))
+		case *parse.IfNode:
+			accent(n.List)
+			accent(n.ElseList)
+		case *parse.RangeNode:
+			accent(n.List)
+			accent(n.ElseList)
+		case *parse.WithNode:
+			accent(n.List)
+			accent(n.ElseList)
+		}
+	}
+	accent(tmpl.Tree.Root)
+	options := pseudo.Options{Expansion: *oPseudoExpansion}
+	return options.Wrap(tmpl.Tree.Root.String(), size), nil
+}
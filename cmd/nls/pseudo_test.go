@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/emicklei/nls/internal/messagefile"
+)
+
+func TestPseudoEntries(t *testing.T) {
+	defer func(source, minState string) { *oSource, *oMinState = source, minState }(*oSource, *oMinState)
+	*oSource, *oMinState = "en", messagefile.StateApproved
+	entries := []Entry{
+		{Language: "en", Key: "cats", Text: "{{.count}} {{- if gt .count 1}} cats{{- else}} cat{{- end}}", Description: "number of cats"},
+		{Language: "en", Key: "empty", Text: ""},
+		{Language: "en", Key: "dogs", Text: "dogs", State: messagefile.StateNeedsReview},
+		{Language: "nl", Key: "cats", Text: "{{.count}} katten"},
+	}
+	pseudo, err := pseudoEntries(entries, "en-XA", "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(pseudo), 2; got != want {
+		t.Fatalf("got [%d] want [%d]", got, want)
+	}
+	want := Entry{Language: "en-XA", Key: "cats", Text: "[{{.count}}{{if gt .count 1}} çáţš{{else}} çáţ{{end}} ~~~]", Description: "number of cats",
+		State: messagefile.StateApproved}
+	if got := pseudo[0]; got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+	// filtered by -min-state like their source entries
+	if !pseudo[0].isRegistered() || pseudo[1].isRegistered() {
+		t.Errorf("got [%v,%v] want [true,false]", pseudo[0].isRegistered(), pseudo[1].isRegistered())
+	}
+}
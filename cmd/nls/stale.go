@@ -0,0 +1,188 @@
+package main
+
+import (
+	"crypto/sha256"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/emicklei/nls/internal/messagefile"
+	"gopkg.in/yaml.v3"
+)
+
+// sourcesFile is the file in the messages directory with the source language texts by their hash,
+// such that the text from which a stale entry was translated can be reported.
+const sourcesFile = "nls-sources.yaml"
+
+// staleEntry is an entry whose source language text changed after it was translated.
+type staleEntry struct {
+	Entry
+	OldHash   string
+	OldSource string // empty if unknown
+	NewSource string
+}
+
+// nls stale -dir messages -source en
+func staleCommand(args []string) error {
+	set := flag.NewFlagSet("stale", flag.ExitOnError)
+	set.StringVar(oDir, "dir", "", "directory with the language directories")
+	set.BoolVar(oVerbose, "v", false, "verbose output")
+	set.StringVar(oSource, "source", "en", "source language")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+	entries, err := loadEntries(*oDir)
+	if err != nil {
+		return err
+	}
+	sources, err := readSources(*oDir)
+	if err != nil {
+		return err
+	}
+	for _, each := range staleEntries(entries, sources) {
+		old := each.OldSource
+		if old == "" {
+			old = "(unknown, source hash " + each.OldHash + ")"
+		}
+		fmt.Printf("%s.%s\n  old: %s\n  new: %s\n", each.Language, each.Key, old, each.NewSource)
+	}
+	return nil
+}
+
+// sourceHash returns a short hash of a source language text.
+func sourceHash(text string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(text)))[:16]
+}
+
+// sourceHashes returns the hash of the source language text of each key.
+func sourceHashes(entries []Entry) map[string]string {
+	hashes := map[string]string{}
+	for _, each := range entries {
+		if each.Language == *oSource && each.Text != "" {
+			hashes[each.Key] = sourceHash(each.Text)
+		}
+	}
+	return hashes
+}
+
+// trackSources sets the source hash of translated entries that have none.
+// If the source text of a translated entry changed, it gets the new hash, keeps the previous one
+// as its old hash and is marked as needing review. The old hash is removed once the entry is reviewed,
+// i.e. its state is set to translated or approved, such that the entry is no longer stale.
+// The current source texts are added to the sources.
+func trackSources(entries []Entry, sources map[string]string) []Entry {
+	for _, each := range entries {
+		if each.Language == *oSource && each.Text != "" {
+			sources[sourceHash(each.Text)] = each.Text
+		}
+	}
+	hashes := sourceHashes(entries)
+	for i := range entries {
+		each := &entries[i]
+		hash, ok := hashes[each.Key]
+		if !ok || each.Language == *oSource {
+			continue
+		}
+		if each.Text == "" && !each.message().IsIntentionallyEmpty() {
+			continue // not translated
+		}
+		if each.SourceHash == "" {
+			each.SourceHash = hash
+			continue
+		}
+		reviewed := messagefile.Progress(each.effectiveState()) > messagefile.Progress(messagefile.StateNeedsReview)
+		if each.SourceHash != hash {
+			if each.OldSourceHash == "" {
+				each.OldSourceHash = each.SourceHash
+			}
+			each.SourceHash = hash
+			if reviewed {
+				if *oVerbose {
+					log.Printf("source of %s.%s changed, it needs review\n", each.Language, each.Key)
+				}
+				each.State = messagefile.StateNeedsReview
+			}
+			continue
+		}
+		if reviewed {
+			each.OldSourceHash = ""
+		}
+	}
+	return entries
+}
+
+// isStale returns whether the source text of the entry changed since it was translated,
+// given the hash of the current source text.
+func (e Entry) isStale(hash string) bool {
+	return e.OldSourceHash != "" || e.SourceHash != "" && hash != "" && e.SourceHash != hash
+}
+
+// oldSourceHash returns the hash of the source text from which a stale entry was translated.
+func (e Entry) oldSourceHash() string {
+	if e.OldSourceHash != "" {
+		return e.OldSourceHash
+	}
+	return e.SourceHash
+}
+
+// staleEntries returns the entries whose source text changed since they were translated, sorted by key.
+func staleEntries(entries []Entry, sources map[string]string) []staleEntry {
+	stale := []staleEntry{}
+	hashes := sourceHashes(entries)
+	for _, each := range entries {
+		hash, ok := hashes[each.Key]
+		if !ok || each.Language == *oSource || !each.isStale(hash) {
+			continue
+		}
+		old := each.oldSourceHash()
+		stale = append(stale, staleEntry{Entry: each, OldHash: old, OldSource: sources[old], NewSource: sources[hash]})
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		if stale[i].Key != stale[j].Key {
+			return stale[i].Key < stale[j].Key
+		}
+		return stale[i].Language < stale[j].Language
+	})
+	return stale
+}
+
+// readSources reads the source texts by their hash; it returns none if the file does not exist.
+func readSources(dir string) (map[string]string, error) {
+	sources := map[string]string{}
+	data, err := os.ReadFile(filepath.Join(dir, sourcesFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return sources, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &sources); err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", sourcesFile, err)
+	}
+	return sources, nil
+}
+
+// writeSources writes the source texts whose hash is used by one of the entries.
+func writeSources(dir string, entries []Entry, sources map[string]string) error {
+	used := map[string]string{}
+	for _, hash := range sourceHashes(entries) {
+		used[hash] = sources[hash]
+	}
+	for _, each := range entries {
+		for _, hash := range []string{each.SourceHash, each.OldSourceHash} {
+			if text, ok := sources[hash]; ok {
+				used[hash] = text
+			}
+		}
+	}
+	data, err := yaml.Marshal(used)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, sourcesFile), data, 0644)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/emicklei/nls/internal/messagefile"
+)
+
+func TestTrackSources(t *testing.T) {
+	defer func(source string) { *oSource = source }(*oSource)
+	*oSource = "en"
+	dir := t.TempDir()
+	entries := []Entry{
+		{Language: "en", Key: "hello", Text: "Hello"},
+		{Language: "nl", Key: "hello", Text: "Hallo"},
+		{Language: "de", Key: "hello", Text: ""},
+	}
+	sources, err := readSources(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries = trackSources(entries, sources)
+	if got, want := entries[1].SourceHash, sourceHash("Hello"); got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := entries[2].SourceHash, ""; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if err := writeSources(dir, entries, sources); err != nil {
+		t.Fatal(err)
+	}
+
+	// next run after the source text changed
+	entries[0].Text = "Hello there"
+	sources, err = readSources(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries = trackSources(entries, sources)
+	if got, want := entries[1].State, messagefile.StateNeedsReview; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := entries[1].SourceHash+","+entries[1].OldSourceHash, sourceHash("Hello there")+","+sourceHash("Hello"); got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if err := writeSources(dir, entries, sources); err != nil {
+		t.Fatal(err)
+	}
+	// still stale in the next run
+	sources, err = readSources(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries = trackSources(entries, sources)
+	stale := staleEntries(entries, sources)
+	if got, want := len(stale), 1; got != want {
+		t.Fatalf("got [%d] want [%d]", got, want)
+	}
+	if got, want := stale[0].OldSource+" > "+stale[0].NewSource, "Hello > Hello there"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+
+	// after review
+	entries[1].State = messagefile.StateTranslated
+	entries = trackSources(entries, sources)
+	if got, want := entries[1].State, messagefile.StateTranslated; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := len(staleEntries(entries, sources)), 0; got != want {
+		t.Errorf("got [%d] want [%d]", got, want)
+	}
+}
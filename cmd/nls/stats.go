@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/emicklei/nls/internal/messagefile"
+)
+
+// languageStats is the translation progress of a language.
+type languageStats struct {
+	Language   string `json:"language"`
+	Total      int    `json:"total"`
+	Translated int    `json:"translated"`
+	Empty      int    `json:"empty"`
+	Stale      int    `json:"stale"`
+	// of the source texts of the keys that are not translated
+	UntranslatedWords      int `json:"untranslatedWords"`
+	UntranslatedCharacters int `json:"untranslatedCharacters"`
+}
+
+// nls stats -dir messages -source en -json
+func statsCommand(args []string) error {
+	set := flag.NewFlagSet("stats", flag.ExitOnError)
+	set.StringVar(oDir, "dir", "", "directory with the language directories")
+	set.BoolVar(oVerbose, "v", false, "verbose output")
+	set.StringVar(oSource, "source", "en", "source language")
+	asJSON := set.Bool("json", false, "write the statistics as JSON")
+	if err := set.Parse(args); err != nil {
+		return err
+	}
+	entries, err := loadEntries(*oDir)
+	if err != nil {
+		return err
+	}
+	stats := translationStats(fillMissingEntries(entries))
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(stats)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "language\ttotal\ttranslated\tempty\tstale\twords\tcharacters\t")
+	for _, each := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t\n", each.Language, each.Total, each.Translated, each.Empty, each.Stale,
+			each.UntranslatedWords, each.UntranslatedCharacters)
+	}
+	return w.Flush()
+}
+
+// translationStats returns the statistics of each language, sorted by language.
+// A regional delta language uses the entries of its base language for the keys it does not have.
+func translationStats(entries []Entry) []languageStats {
+	byLanguage := map[string]map[string]Entry{}
+	keys := map[string]bool{}
+	for _, each := range entries {
+		if byLanguage[each.Language] == nil {
+			byLanguage[each.Language] = map[string]Entry{}
+		}
+		byLanguage[each.Language][each.Key] = each
+		keys[each.Key] = true
+	}
+	source := byLanguage[*oSource]
+	hashes := sourceHashes(entries)
+	stats := []languageStats{}
+	for lang := range byLanguage {
+		s := languageStats{Language: lang, Total: len(keys)}
+		for key := range keys {
+			each, ok := lookupEntry(byLanguage, lang, key)
+			if !ok || each.Text == "" && !each.message().IsIntentionallyEmpty() {
+				s.Empty++
+			}
+			if ok && each.isStale(hashes[key]) {
+				s.Stale++
+			}
+			if ok && messagefile.Progress(each.effectiveState()) >= messagefile.Progress(messagefile.StateTranslated) {
+				s.Translated++
+				continue
+			}
+			words, characters := textSize(source[key].Text)
+			s.UntranslatedWords += words
+			s.UntranslatedCharacters += characters
+		}
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Language < stats[j].Language })
+	return stats
+}
+
+// lookupEntry returns the entry of a key in a language or, if absent, in its base language.
+func lookupEntry(byLanguage map[string]map[string]Entry, lang, key string) (Entry, bool) {
+	for {
+		if each, ok := byLanguage[lang][key]; ok {
+			return each, true
+		}
+		i := strings.LastIndex(lang, "-")
+		if i < 0 {
+			return Entry{}, false
+		}
+		lang = lang[:i]
+	}
+}
+
+var templateAction = regexp.MustCompile(`{{.*?}}`)
+
+// textSize returns the number of words and characters of a text without its template actions.
+// A word has at least one letter or digit.
+func textSize(text string) (words, characters int) {
+	text = strings.TrimSpace(templateAction.ReplaceAllString(text, ""))
+	for _, each := range strings.Fields(text) {
+		if strings.IndexFunc(each, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			words++
+		}
+	}
+	return words, utf8.RuneCountInString(text)
+}
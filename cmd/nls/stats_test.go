@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/emicklei/nls/internal/messagefile"
+)
+
+func TestTranslationStats(t *testing.T) {
+	defer func(source string) { *oSource = source }(*oSource)
+	*oSource = "en"
+	entries := []Entry{
+		{Language: "en", Key: "hello", Text: "Hello {{.name}}, welcome"},
+		{Language: "en", Key: "color", Text: "color"},
+		{Language: "en", Key: "bye", Text: "Bye"},
+		{Language: "nl", Key: "hello", Text: "Hallo {{.name}}", SourceHash: "0000000000000000", State: messagefile.StateNeedsReview},
+		{Language: "nl", Key: "color", Text: "kleur"},
+		{Language: "nl", Key: "bye"},
+		{Language: "en-GB", Key: "color", Text: "colour"},
+	}
+	stats := translationStats(entries)
+	if got, want := len(stats), 3; got != want {
+		t.Fatalf("got [%d] want [%d]", got, want)
+	}
+	want := []languageStats{
+		{Language: "en", Total: 3, Translated: 3},
+		{Language: "en-GB", Total: 3, Translated: 3},
+		{Language: "nl", Total: 3, Translated: 1, Empty: 1, Stale: 1, UntranslatedWords: 3, UntranslatedCharacters: 18},
+	}
+	for i := range want {
+		if stats[i] != want[i] {
+			t.Errorf("got [%+v] want [%+v]", stats[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/emicklei/nls/internal/messagefile"
+)
+
+// https://www.gala-global.org/tmx-14b
+type tmxDocument struct {
+	XMLName xml.Name  `xml:"tmx"`
+	Version string    `xml:"version,attr"`
+	Header  tmxHeader `xml:"header"`
+	Units   []tmxUnit `xml:"body>tu"`
+}
+
+type tmxHeader struct {
+	CreationTool        string `xml:"creationtool,attr"`
+	CreationToolVersion string `xml:"creationtoolversion,attr"`
+	SegType             string `xml:"segtype,attr"`
+	OTmf                string `xml:"o-tmf,attr"`
+	AdminLang           string `xml:"adminlang,attr"`
+	SrcLang             string `xml:"srclang,attr"`
+	DataType            string `xml:"datatype,attr"`
+}
+
+type tmxUnit struct {
+	TUID     string       `xml:"tuid,attr,omitempty"`
+	Note     string       `xml:"note,omitempty"`
+	Variants []tmxVariant `xml:"tuv"`
+}
+
+type tmxVariant struct {
+	Lang    string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Segment string `xml:"seg"`
+}
+
+// exportTMX writes messages.tmx, a TMX 1.4 translation memory with a unit per key
+// that has a text in the source language, aligned with the texts of the other languages.
+func exportTMX(entries []Entry, out string) error {
+	rows, languages := csvRows(entries)
+	doc := tmxDocument{Version: "1.4", Header: tmxHeader{
+		CreationTool:        "nls",
+		CreationToolVersion: "1",
+		SegType:             "block",
+		OTmf:                "yaml",
+		AdminLang:           *oSource,
+		SrcLang:             *oSource,
+		DataType:            "plaintext",
+	}}
+	for _, each := range rows {
+		if each.Texts[*oSource] == "" {
+			continue
+		}
+		unit := tmxUnit{TUID: each.Key, Note: each.Description}
+		for _, lang := range languages {
+			if text := each.Texts[lang]; text != "" {
+				unit.Variants = append(unit.Variants, tmxVariant{Lang: lang, Segment: text})
+			}
+		}
+		doc.Units = append(doc.Units, unit)
+	}
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(out, os.ModePerm); err != nil {
+		return err
+	}
+	fileName := filepath.Join(out, "messages.tmx")
+	if *oVerbose {
+		log.Printf("writing %s\n", fileName)
+	}
+	return os.WriteFile(fileName, append([]byte(xml.Header), append(data, '\n')...), 0644)
+}
+
+// importTMX fills the empty entries of the existing (and missing) messages with exact matches from a TMX file.
+// A match is a unit with a variant in the source language that has the same text as the source entry of the key,
+// and a variant in the language of the empty entry. Filled entries are marked as needing review.
+func importTMX(in string, existing []Entry) ([]Entry, error) {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return nil, err
+	}
+	var doc tmxDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", in, err)
+	}
+	// source text -> language -> text
+	memory := map[string]map[string]string{}
+	for _, unit := range doc.Units {
+		source, ok := tmxSegment(unit, *oSource)
+		if !ok {
+			continue
+		}
+		translations, ok := memory[source]
+		if !ok {
+			translations = map[string]string{}
+			memory[source] = translations
+		}
+		for _, each := range unit.Variants {
+			translations[strings.ToLower(each.Lang)] = each.Segment
+		}
+	}
+	sourceTexts := map[string]string{}
+	for _, each := range existing {
+		if each.Language == *oSource {
+			sourceTexts[each.Key] = each.Text
+		}
+	}
+	filled := []Entry{}
+	for _, each := range fillMissingEntries(append([]Entry{}, existing...)) {
+		if each.Language == *oSource || each.Text != "" || sourceTexts[each.Key] == "" {
+			continue
+		}
+		translations, ok := memory[sourceTexts[each.Key]]
+		if !ok {
+			continue
+		}
+		text, ok := tmxTranslation(translations, each.Language)
+		if !ok {
+			continue
+		}
+		filled = append(filled, Entry{Language: each.Language, Key: each.Key, Text: text, State: messagefile.StateNeedsReview})
+	}
+	if *oVerbose {
+		log.Printf("%d empty messages filled from %s\n", len(filled), in)
+	}
+	return filled, nil
+}
+
+// tmxSegment returns the text of the variant in the language.
+func tmxSegment(unit tmxUnit, lang string) (string, bool) {
+	translations := map[string]string{}
+	for _, each := range unit.Variants {
+		translations[strings.ToLower(each.Lang)] = each.Segment
+	}
+	return tmxTranslation(translations, lang)
+}
+
+// tmxTranslation returns the text for the language, e.g. nl, or else for a regional variant of it, e.g. nl-NL.
+func tmxTranslation(translations map[string]string, lang string) (string, bool) {
+	lang = strings.ToLower(lang)
+	if text, ok := translations[lang]; ok {
+		return text, true
+	}
+	for each, text := range translations {
+		if strings.HasPrefix(each, lang+"-") {
+			return text, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/emicklei/nls/internal/messagefile"
+)
+
+func TestExportImportTMX(t *testing.T) {
+	defer func(source string) { *oSource = source }(*oSource)
+	*oSource = "en"
+	dir := t.TempDir()
+	memory := []Entry{
+		{Language: "en", Key: "sky", Text: "Sky"},
+		{Language: "nl", Key: "sky", Text: "Lucht"},
+	}
+	if err := exportTMX(memory, dir); err != nil {
+		t.Fatal(err)
+	}
+	existing := []Entry{
+		{Language: "en", Key: "heaven", Text: "Sky"},
+		{Language: "nl", Key: "heaven", Text: ""},
+		{Language: "en", Key: "world", Text: "world"},
+	}
+	filled, err := importTMX(filepath.Join(dir, "messages.tmx"), existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(filled), 1; got != want {
+		t.Fatalf("got [%d] want [%d]", got, want)
+	}
+	if got, want := filled[0], (Entry{Language: "nl", Key: "heaven", Text: "Lucht", State: messagefile.StateNeedsReview}); got != want {
+		t.Errorf("got [%v] want [%v]", got, want)
+	}
+}
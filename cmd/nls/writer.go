@@ -7,10 +7,19 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 func writeEntries(entries []Entry, dir string) error {
-	mme := entriesToMap(entries)
+	// messages from Fluent files are not written to YAML
+	yamlEntries := []Entry{}
+	for _, each := range entries {
+		if filepath.Ext(each.File) != ".ftl" {
+			yamlEntries = append(yamlEntries, each)
+		}
+	}
+	mme := entriesToMap(yamlEntries)
 	for lang, langMap := range mme {
 		if err := writeLangFile(lang, langMap, dir); err != nil {
 			return err
@@ -46,6 +55,9 @@ func entriesToMap(entries []Entry) map[string]map[string]Entry {
 
 func writeLangFile(lang string, langMap map[string]Entry, dir string) error {
 	fileName := filepath.Join(dir, lang, "messages.yaml")
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		return err
+	}
 	out, err := os.Create(fileName)
 	if err != nil {
 		return err
@@ -65,8 +77,8 @@ func writeLangFile(lang string, langMap map[string]Entry, dir string) error {
 			fmt.Fprintln(out, each.Comment)
 		}
 		fmt.Fprintf(out, "%s: ", each.Key)
-		if each.Description != "" {
-			writeNestedYAMLString(out, each.Text, each.Description)
+		if each.Description != "" || each.State != "" || each.SourceHash != "" || each.OldSourceHash != "" {
+			writeNestedYAMLString(out, each)
 		} else {
 			writeYAMLString(out, each.Text)
 		}
@@ -81,43 +93,45 @@ var quoteit = "{}[],&*#?|-<>=!%@"
 //
 //	msg: value
 //	desc: explanation of the context in which the value is used
-func writeNestedYAMLString(w io.Writer, msg string, desc string) {
+//	state: translated
+//	srchash: 8d969eef6ecad3c2
+//	oldsrchash: 5e884898da280471
+//
+// The desc is omitted if empty and the state or srchash is set; the state and hashes are omitted if empty.
+func writeNestedYAMLString(w io.Writer, e Entry) {
 	fmt.Fprintln(w)
-
 	fmt.Fprint(w, "  msg: ")
-	if msg == "" {
-		fmt.Fprintln(w)
-	} else if strings.Contains(msg, "\n") {
-		fmt.Fprintln(w, "|")
-		lines := strings.Split(msg, "\n")
-		for i, line := range lines {
-			if line == "" && i == len(lines)-1 {
-				continue
-			}
-			fmt.Fprintf(w, "    %s\n", line)
-		}
-	} else if strings.ContainsAny(msg, quoteit) {
-		fmt.Fprintf(w, "'%s'\n", msg)
-	} else {
-		fmt.Fprintf(w, "%s\n", msg)
+	writeNestedValue(w, e.Text)
+	if e.Description != "" || (e.State == "" && e.SourceHash == "") {
+		fmt.Fprint(w, "  desc: ")
+		writeNestedValue(w, e.Description)
+	}
+	if e.State != "" {
+		fmt.Fprintf(w, "  state: %s\n", e.State)
 	}
+	if e.SourceHash != "" {
+		fmt.Fprintf(w, "  srchash: %s\n", e.SourceHash)
+	}
+	if e.OldSourceHash != "" {
+		fmt.Fprintf(w, "  oldsrchash: %s\n", e.OldSourceHash)
+	}
+}
 
-	fmt.Fprint(w, "  desc: ")
-	if desc == "" {
+// writeNestedValue writes a value of a nested key, indented as a block if it has multiple lines.
+func writeNestedValue(w io.Writer, s string) {
+	if s == "" {
 		fmt.Fprintln(w)
-	} else if strings.Contains(desc, "\n") {
+	} else if strings.Contains(s, "\n") {
 		fmt.Fprintln(w, "|")
-		lines := strings.Split(desc, "\n")
+		lines := strings.Split(s, "\n")
 		for i, line := range lines {
 			if line == "" && i == len(lines)-1 {
 				continue
 			}
 			fmt.Fprintf(w, "    %s\n", line)
 		}
-	} else if strings.ContainsAny(desc, quoteit) {
-		fmt.Fprintf(w, "'%s'\n", desc)
 	} else {
-		fmt.Fprintf(w, "%s\n", desc)
+		fmt.Fprintf(w, "%s\n", yamlScalar(s))
 	}
 }
 
@@ -137,9 +151,20 @@ func writeYAMLString(w io.Writer, s string) {
 		}
 		return
 	}
-	if strings.ContainsAny(s, quoteit) {
-		fmt.Fprintf(w, "'%s'\n", s)
-		return
+	fmt.Fprintf(w, "%s\n", yamlScalar(s))
+}
+
+// yamlScalar returns a single line value as is if it reads back as the same string,
+// otherwise single quoted with its quotes doubled.
+func yamlScalar(s string) string {
+	if !strings.ContainsAny(s, quoteit) {
+		var node yaml.Node
+		if err := yaml.Unmarshal([]byte("k: "+s), &node); err == nil && len(node.Content) > 0 {
+			value := node.Content[0].Content[1]
+			if value.Tag == "!!str" && value.Value == s {
+				return s
+			}
+		}
 	}
-	fmt.Fprintf(w, "%s\n", s)
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// https://developer.apple.com/documentation/xcode/localizing-and-varying-text-with-a-string-catalog
+type xcCatalog struct {
+	SourceLanguage string              `json:"sourceLanguage"`
+	Strings        map[string]xcString `json:"strings"`
+	Version        string              `json:"version"`
+}
+
+type xcString struct {
+	Comment         string                    `json:"comment,omitempty"`
+	ExtractionState string                    `json:"extractionState"`
+	Localizations   map[string]xcLocalization `json:"localizations"`
+}
+
+type xcLocalization struct {
+	StringUnit *xcStringUnit `json:"stringUnit,omitempty"`
+	Variations *xcVariations `json:"variations,omitempty"`
+}
+
+type xcVariations struct {
+	Plural map[string]xcLocalization `json:"plural"`
+}
+
+type xcStringUnit struct {
+	State string `json:"state"`
+	Value string `json:"value"`
+}
+
+// exportXCStrings writes a Localizable.xcstrings with all languages.
+// A single placeholder is written as %@, multiple as positional %1$@ in sorted order of their names;
+// the count of a plural is written as %lld. Descriptions become comments for translators.
+func exportXCStrings(entries []Entry, out string) error {
+	var skipped refused
+	list, params := analyseEntries(entries, &skipped)
+	skipped.report("xcstrings")
+	cat := xcCatalog{SourceLanguage: *oSource, Strings: map[string]xcString{}, Version: "1.0"}
+	for _, each := range list {
+		str, ok := cat.Strings[each.Key]
+		if !ok {
+			str = xcString{ExtractionState: "manual", Localizations: map[string]xcLocalization{}}
+		}
+		if str.Comment == "" {
+			str.Comment = each.Description
+		}
+		names := params[each.Key]
+		countParam := ""
+		if each.Plural != nil {
+			countParam = each.Plural.Param
+		}
+		arg := func(name string) string {
+			verb := "@"
+			if name == countParam {
+				verb = "lld"
+			}
+			if len(names) == 1 {
+				return "%" + verb
+			}
+			return fmt.Sprintf("%%%d$%s", slices.Index(names, name)+1, verb)
+		}
+		if each.Plural == nil {
+			str.Localizations[each.Language] = xcUnit(each.Segments, len(names) > 0, arg)
+		} else {
+			str.Localizations[each.Language] = xcLocalization{Variations: &xcVariations{
+				Plural: map[string]xcLocalization{
+					"one":   xcUnit(each.Plural.One, true, arg),
+					"other": xcUnit(each.Plural.Other, true, arg),
+				}}}
+		}
+		cat.Strings[each.Key] = str
+	}
+	data, err := json.MarshalIndent(cat, "", "  ")
+	if err != nil {
+		return err
+	}
+	fileName := filepath.Join(out, "Localizable.xcstrings")
+	if *oVerbose {
+		log.Printf("writing %s\n", fileName)
+	}
+	if err := os.MkdirAll(out, os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, append(data, '\n'), 0644)
+}
+
+func xcUnit(segments []segment, formatted bool, arg func(string) string) xcLocalization {
+	escaped := []segment{}
+	for _, each := range segments {
+		if each.Param == "" && formatted {
+			each.Text = strings.ReplaceAll(each.Text, "%", "%%")
+		}
+		escaped = append(escaped, each)
+	}
+	return xcLocalization{StringUnit: &xcStringUnit{State: "translated", Value: formatSegments(escaped, arg)}}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestXCUnit(t *testing.T) {
+	arg := func(name string) string { return "%@" }
+	for _, each := range []struct {
+		segments  []segment
+		formatted bool
+		want      string
+	}{
+		{[]segment{{Text: "100% sure"}}, false, "100% sure"},
+		{[]segment{{Text: "100% "}, {Param: "name"}}, true, "100%% %@"},
+	} {
+		if got := xcUnit(each.segments, each.formatted, arg).StringUnit.Value; got != each.want {
+			t.Errorf("got [%s] want [%s]", got, each.want)
+		}
+	}
+}
+
+func TestExportXCStrings(t *testing.T) {
+	defer func(source string) { *oSource = source }(*oSource)
+	*oSource = "en"
+	out := t.TempDir()
+	err := exportXCStrings([]Entry{
+		{Language: "en", Key: "hello", Text: "Hello {{.name}}", Description: "greeting"},
+		{Language: "nl", Key: "hello", Text: "Hallo {{.name}}"},
+		{Language: "en", Key: "cats", Text: "{{if eq .n 1}}one cat of {{.name}}{{else}}{{.n}} cats of {{.name}}{{end}}"},
+		{Language: "en", Key: "count", Text: "{{if gt .n 1}}{{.n}} items{{else}}one item{{end}}"},
+	}, out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(out, "Localizable.xcstrings"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cat xcCatalog
+	if err := json.Unmarshal(data, &cat); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cat.Strings["hello"].Comment, "greeting"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	for lang, want := range map[string]string{"en": "Hello %@", "nl": "Hallo %@"} {
+		if got := cat.Strings["hello"].Localizations[lang].StringUnit.Value; got != want {
+			t.Errorf("got [%s] want [%s]", got, want)
+		}
+	}
+	for key, forms := range map[string]map[string]string{
+		"cats":  {"one": "one cat of %2$@", "other": "%1$lld cats of %2$@"},
+		"count": {"one": "one item", "other": "%lld items"},
+	} {
+		plural := cat.Strings[key].Localizations["en"].Variations.Plural
+		for form, want := range forms {
+			if got := plural[form].StringUnit.Value; got != want {
+				t.Errorf("%s %s: got [%s] want [%s]", key, form, got, want)
+			}
+		}
+	}
+}
@@ -3,8 +3,9 @@
 package nls
 
 import (
-	"text/template"
 	"context"
+	"io/fs"
+	"time"
 	"golang.org/x/text/language"
 
 	NLS "github.com/emicklei/nls"
@@ -23,10 +24,18 @@ const (
 	M_world = "world"
 )
 
-
 var (
-	// messages is a map of language-key to message template.
-	messages = make(map[string]*template.Template,16)
+	// messages is a table of message templates per language, indexed by message ID.
+	messages = NLS.NewTable(
+		"bestaat",
+		"cats",
+		"hello",
+		"multi",
+		"sea",
+		"sky",
+		"trends2",
+		"world",
+	)
 
 	// https://pkg.go.dev/golang.org/x/text/language
 	Languages = []language.Tag{
@@ -34,31 +43,59 @@ var (
 	 	language.MustParse("nl"),
 	}
 	LanguageMatcher = language.NewMatcher(Languages)
+
+	// Completeness has the number of translated messages per language, computed when generating.
+	Completeness = map[language.Tag]NLS.Completeness{
+		language.MustParse("en"): {Translated: 6, Total: 8},
+		language.MustParse("nl"): {Translated: 7, Total: 8},
+	}
 )
 
 func init() {
-	NLS.Register(messages,"en.cats",`{{.count}} {{- if gt .count 1}} cats{{- else}} cat{{- end}}`)
-	NLS.Register(messages,"en.multi",`{{.name}} says hello
+	messages.Register(1, "en", `{{.count}} {{- if gt .count 1}} cats{{- else}} cat{{- end}}`)
+	messages.Register(3, "en", `{{.name}} says hello
 to the world
 `)
-	NLS.Register(messages,"en.sea",`{{.color }} sea`)
-	NLS.Register(messages,"en.sky",`Sky`)
-	NLS.Register(messages,"en.trends2",`{{.value}} trends`)
-	NLS.Register(messages,"en.world",`world`)
-	NLS.Register(messages,"nl.bestaat",`wel`)
-	NLS.Register(messages,"nl.cats",`{{.count}} {{- if gt .count 1}} katten{{- else}} kat{{- end}}`)
-	NLS.Register(messages,"nl.hello",`hallo`)
-	NLS.Register(messages,"nl.multi",`{{.name}} zegt hallo
+	messages.Register(4, "en", `{{.color }} sea`)
+	messages.RegisterText(5, "en", "Sky")
+	messages.Register(6, "en", `{{.value}} trends`)
+	messages.RegisterText(7, "en", "world")
+	messages.RegisterText(0, "nl", "wel")
+	messages.Register(1, "nl", `{{.count}} {{- if gt .count 1}} katten{{- else}} kat{{- end}}`)
+	messages.RegisterText(2, "nl", "hallo")
+	messages.Register(3, "nl", `{{.name}} zegt hallo
 tegen de wereld
 `)
-	NLS.Register(messages,"nl.sea",`{{.name }} zee`)
-	NLS.Register(messages,"nl.trends2",`{{.value}} trends`)
-	NLS.Register(messages,"nl.world",`wereld`)
+	messages.Register(4, "nl", `{{.name }} zee`)
+	messages.Register(6, "nl", `{{.value}} trends`)
+	messages.RegisterText(7, "nl", "wereld")
+}
+
+// LanguagesCompleted returns the Languages of which at least a fraction (e.g. 0.9) of the messages is translated.
+func LanguagesCompleted(fraction float64) []language.Tag {
+	tags := []language.Tag{}
+	for _, each := range Languages {
+		if Completeness[each].Ratio() >= fraction {
+			tags = append(tags, each)
+		}
+	}
+	return tags
 }
 
 // New returns a Localizer with zero or more languages.
 func New(languages ...string) NLS.Localizer {
-	return NLS.NewLocalizer(messages, languages...)
+	return NLS.NewTableLocalizer(messages, languages...)
+}
+
+// Catalog returns the messages, e.g. to use them as a layer of NLS.Layers or as a base of NLS.Tenants.
+func Catalog() NLS.Catalog {
+	return messages
+}
+
+// Watch reloads the messages from the message files in the language directories of dir when they change.
+// Existing Localizers use the reloaded messages. It is meant for development.
+func Watch(ctx context.Context, fsys fs.FS, dir string) error {
+	return NLS.WatchTable(ctx, messages, fsys, dir, time.Second)
 }
 
 // Get a localized string by its message ID, with an optional fallback.
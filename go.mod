@@ -3,6 +3,7 @@ module github.com/emicklei/nls
 go 1.19
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	golang.org/x/text v0.14.0
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,390 @@
+package messagefile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// fluentEntry is a message or term of a Fluent file before it is compiled into a template.
+type fluentEntry struct {
+	ID         string
+	Pattern    string
+	Attributes [][2]string // name and pattern in order of appearance
+	Comment    string
+}
+
+var (
+	fluentEntryStart = regexp.MustCompile(`^(-?[a-zA-Z][a-zA-Z0-9_-]*)\s*=(.*)$`)
+	fluentAttribute  = regexp.MustCompile(`^\s+\.([a-zA-Z][a-zA-Z0-9_-]*)\s*=(.*)$`)
+)
+
+// DecodeFluent reads the messages of a Project Fluent (.ftl) file.
+// Each message becomes a message and each attribute a message with key "message.attribute".
+// Terms are inlined where they are referenced, variables { $name } become {{.name}} and
+// selectors become {{if eq .name key}}...{{else}}...{{end}}; the plural category one is compared to 1.
+// The comment directly above a message becomes its description.
+// It returns the messages that compile and an error for each that does not.
+func DecodeFluent(r io.Reader) ([]Message, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	parsed := parseFluent(string(data))
+	resources := map[string]fluentEntry{}
+	for _, each := range parsed {
+		resources[each.ID] = each
+	}
+	messages := []Message{}
+	errs := []error{}
+	for _, each := range parsed {
+		if strings.HasPrefix(each.ID, "-") {
+			continue // term
+		}
+		patterns := [][2]string{{each.ID, each.Pattern}}
+		for _, attr := range each.Attributes {
+			patterns = append(patterns, [2]string{each.ID + "." + attr[0], attr[1]})
+		}
+		for _, pattern := range patterns {
+			if pattern[1] == "" {
+				continue
+			}
+			c := &fluentCompiler{input: []rune(pattern[1]), resources: resources, depth: 1}
+			text, err := c.compile(false)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cannot compile %s: %w", pattern[0], err))
+				continue
+			}
+			messages = append(messages, Message{Key: pattern[0], Text: text, Description: each.Comment})
+		}
+	}
+	return messages, errors.Join(errs...)
+}
+
+// parseFluent splits the source into messages and terms with their (multiline) patterns and attributes.
+func parseFluent(source string) []fluentEntry {
+	entries := []fluentEntry{}
+	var current *fluentEntry
+	var lines []string // of the pattern being read
+	attribute := -1    // index of the attribute being read or -1 for the value
+	comment := []string{}
+	flush := func() {
+		if current == nil {
+			return
+		}
+		pattern := dedentPattern(lines)
+		if attribute == -1 {
+			current.Pattern = pattern
+		} else {
+			current.Attributes[attribute][1] = pattern
+		}
+		lines = nil
+	}
+	finish := func() {
+		flush()
+		if current != nil {
+			entries = append(entries, *current)
+		}
+		current = nil
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "#"):
+			finish()
+			if strings.HasPrefix(line, "##") {
+				comment = nil
+				continue
+			}
+			comment = append(comment, strings.TrimSpace(strings.TrimPrefix(line, "#")))
+		case strings.TrimSpace(line) == "":
+			if current == nil {
+				comment = nil
+			} else {
+				lines = append(lines, "")
+			}
+		case line[0] == ' ' || line[0] == '\t' || line[0] == '}' || line[0] == '[' || line[0] == '*' || line[0] == '.':
+			if current == nil {
+				continue
+			}
+			if m := fluentAttribute.FindStringSubmatch(line); m != nil && braceDepth(lines) == 0 {
+				flush()
+				current.Attributes = append(current.Attributes, [2]string{m[1], ""})
+				attribute = len(current.Attributes) - 1
+				lines = []string{m[2]}
+				continue
+			}
+			lines = append(lines, line)
+		default:
+			finish()
+			m := fluentEntryStart.FindStringSubmatch(line)
+			if m == nil {
+				comment = nil
+				continue // junk
+			}
+			current = &fluentEntry{ID: m[1], Comment: strings.Join(comment, "\n")}
+			comment = nil
+			attribute = -1
+			lines = []string{m[2]}
+		}
+	}
+	finish()
+	return entries
+}
+
+// braceDepth returns the number of open placeables in the lines.
+func braceDepth(lines []string) int {
+	depth := 0
+	for _, each := range lines {
+		depth += strings.Count(each, "{") - strings.Count(each, "}")
+	}
+	return depth
+}
+
+// dedentPattern joins the first line and the continuation lines without their common indentation.
+func dedentPattern(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	indent := -1
+	for _, each := range lines[1:] {
+		if strings.TrimSpace(each) == "" {
+			continue
+		}
+		n := len(each) - len(strings.TrimLeft(each, " \t"))
+		if indent == -1 || n < indent {
+			indent = n
+		}
+	}
+	result := []string{strings.TrimSpace(lines[0])}
+	for _, each := range lines[1:] {
+		if len(each) >= indent && indent > 0 {
+			each = each[indent:]
+		}
+		result = append(result, strings.TrimRight(each, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
+
+// fluentCompiler translates a Fluent pattern into a Go template.
+type fluentCompiler struct {
+	input     []rune
+	pos       int
+	resources map[string]fluentEntry
+	depth     int // of nested references
+}
+
+func (c *fluentCompiler) peek() rune {
+	if c.pos < len(c.input) {
+		return c.input[c.pos]
+	}
+	return 0
+}
+
+func (c *fluentCompiler) skipSpace() {
+	for c.pos < len(c.input) && strings.ContainsRune(" \t\n", c.input[c.pos]) {
+		c.pos++
+	}
+}
+
+// atVariantEnd returns whether a variant pattern ends at a newline followed by the next variant or the closing brace.
+func (c *fluentCompiler) atVariantEnd() bool {
+	if c.peek() != '\n' {
+		return false
+	}
+	rest := strings.TrimLeft(string(c.input[c.pos:]), " \t\n")
+	return strings.HasPrefix(rest, "[") || strings.HasPrefix(rest, "*[") || strings.HasPrefix(rest, "}")
+}
+
+// compile reads text and placeables up to the end or, if in a variant, up to the end of the variant.
+func (c *fluentCompiler) compile(variant bool) (string, error) {
+	b := new(strings.Builder)
+	for c.pos < len(c.input) {
+		if variant && (c.atVariantEnd() || c.peek() == '}') {
+			break
+		}
+		r := c.input[c.pos]
+		if r == '{' {
+			c.pos++
+			placeable, err := c.placeable()
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(placeable)
+			continue
+		}
+		if r == '}' {
+			return "", fmt.Errorf("unexpected } at %d", c.pos)
+		}
+		b.WriteRune(r)
+		c.pos++
+	}
+	return b.String(), nil
+}
+
+var fluentIdentifier = regexp.MustCompile(`^-?[a-zA-Z][a-zA-Z0-9_-]*(\.[a-zA-Z][a-zA-Z0-9_-]*)?`)
+
+// placeable reads after the opening brace up to and including the closing brace.
+func (c *fluentCompiler) placeable() (string, error) {
+	c.skipSpace()
+	rest := string(c.input[c.pos:])
+	var selector, text string
+	switch {
+	case c.peek() == '"':
+		end := strings.Index(rest[1:], `"`)
+		if end == -1 {
+			return "", fmt.Errorf("missing \" of string literal")
+		}
+		literal, err := strconv.Unquote(rest[:end+2])
+		if err != nil {
+			return "", err
+		}
+		c.pos += len([]rune(rest[:end+2]))
+		text = literal
+		if strings.ContainsAny(literal, "{}") {
+			text = "{{" + strconv.Quote(literal) + "}}"
+		}
+	case c.peek() == '$':
+		name := fluentIdentifier.FindString(rest[1:])
+		if name == "" || strings.Contains(name, ".") {
+			return "", fmt.Errorf("invalid variable at %d", c.pos)
+		}
+		c.pos += 1 + len([]rune(name))
+		selector = "." + name
+		text = "{{" + selector + "}}"
+	case c.peek() >= '0' && c.peek() <= '9':
+		number := regexp.MustCompile(`^[0-9]+(\.[0-9]+)?`).FindString(rest)
+		c.pos += len(number)
+		text = number
+	default:
+		id := fluentIdentifier.FindString(rest)
+		if id == "" {
+			return "", fmt.Errorf("unsupported expression at %d", c.pos)
+		}
+		c.pos += len([]rune(id))
+		if c.skipSpace(); c.peek() == '(' {
+			return "", fmt.Errorf("unsupported call of %s", id)
+		}
+		resolved, err := c.reference(id)
+		if err != nil {
+			return "", err
+		}
+		text = resolved
+	}
+	c.skipSpace()
+	if strings.HasPrefix(string(c.input[c.pos:]), "->") {
+		if selector == "" {
+			return "", fmt.Errorf("unsupported selector at %d", c.pos)
+		}
+		c.pos += 2
+		return c.selectExpression(selector)
+	}
+	if c.peek() != '}' {
+		return "", fmt.Errorf("missing } at %d", c.pos)
+	}
+	c.pos++
+	return text, nil
+}
+
+// reference returns the compiled pattern of a term or message (attribute) with the id.
+func (c *fluentCompiler) reference(id string) (string, error) {
+	if c.depth > 10 {
+		return "", fmt.Errorf("too deeply nested reference %s", id)
+	}
+	name, attr, _ := strings.Cut(id, ".")
+	resource, ok := c.resources[name]
+	if !ok {
+		return "", fmt.Errorf("unknown reference %s", id)
+	}
+	pattern := resource.Pattern
+	if attr != "" {
+		pattern = ""
+		for _, each := range resource.Attributes {
+			if each[0] == attr {
+				pattern = each[1]
+			}
+		}
+	}
+	nested := &fluentCompiler{input: []rune(pattern), resources: c.resources, depth: c.depth + 1}
+	return nested.compile(false)
+}
+
+// selectExpression reads the variants up to and including the closing brace.
+func (c *fluentCompiler) selectExpression(selector string) (string, error) {
+	type variant struct{ key, text string }
+	variants := []variant{}
+	otherwise, hasDefault := "", false
+	for {
+		c.skipSpace()
+		if c.peek() == '}' {
+			c.pos++
+			break
+		}
+		isDefault := false
+		if c.peek() == '*' {
+			isDefault = true
+			c.pos++
+		}
+		if c.peek() != '[' {
+			return "", fmt.Errorf("missing variant key at %d", c.pos)
+		}
+		end := strings.Index(string(c.input[c.pos:]), "]")
+		if end == -1 {
+			return "", fmt.Errorf("missing ] at %d", c.pos)
+		}
+		key := strings.TrimSpace(string(c.input[c.pos+1 : c.pos+end]))
+		c.pos += end + 1
+		for c.peek() == ' ' || c.peek() == '\t' {
+			c.pos++
+		}
+		text, err := c.compile(true)
+		if err != nil {
+			return "", err
+		}
+		text = strings.TrimSpace(text)
+		if isDefault {
+			otherwise, hasDefault = text, true
+		} else {
+			variants = append(variants, variant{key: key, text: text})
+		}
+	}
+	if !hasDefault {
+		return "", fmt.Errorf("selector %s has no default variant", selector)
+	}
+	b := new(strings.Builder)
+	for i, each := range variants {
+		value, err := fluentVariantValue(each.key)
+		if err != nil {
+			return "", err
+		}
+		if i == 0 {
+			fmt.Fprintf(b, "{{if eq %s %s}}", selector, value)
+		} else {
+			fmt.Fprintf(b, "{{else if eq %s %s}}", selector, value)
+		}
+		b.WriteString(each.text)
+	}
+	if len(variants) == 0 {
+		return otherwise, nil
+	}
+	fmt.Fprintf(b, "{{else}}%s{{end}}", otherwise)
+	return b.String(), nil
+}
+
+// fluentVariantValue returns the Go template operand to compare a selector with.
+func fluentVariantValue(key string) (string, error) {
+	switch key {
+	case "zero":
+		return "0", nil
+	case "one":
+		return "1", nil
+	case "two", "few", "many":
+		return "", fmt.Errorf("unsupported plural category %s", key)
+	}
+	if _, err := strconv.ParseFloat(key, 64); err == nil {
+		return key, nil
+	}
+	return strconv.Quote(key), nil
+}
@@ -0,0 +1,118 @@
+// Package messagefile reads the YAML and Fluent message files of a language directory.
+// It is shared by the nls tool and the runtime loading of catalogs.
+package messagefile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Message is a message of a language as read from a YAML file.
+type Message struct {
+	Key           string
+	Text          string
+	Description   string
+	Comment       string
+	State         string // empty if not set explicitly, see EffectiveState
+	SourceHash    string // of the source language text that the text is translated from, empty if unknown
+	OldSourceHash string // of the source language text before it changed, empty if the text is reviewed since
+}
+
+// States of a message, in order of progress.
+const (
+	StateNew         = "new"
+	StateNeedsReview = "needs-review"
+	StateTranslated  = "translated"
+	StateApproved    = "approved"
+)
+
+var states = []string{StateNew, StateNeedsReview, StateTranslated, StateApproved}
+
+// Progress returns the position of a state in the order new, needs-review, translated, approved
+// or -1 if the state is unknown.
+func Progress(state string) int {
+	return slices.Index(states, state)
+}
+
+// EffectiveState returns the explicit state of a message or, if absent,
+// new for a message without text and translated otherwise.
+func (m Message) EffectiveState() string {
+	if m.State != "" {
+		return m.State
+	}
+	if m.Text == "" {
+		return StateNew
+	}
+	return StateTranslated
+}
+
+// IsIntentionallyEmpty returns whether a message has no text on purpose, i.e. it is translated as empty.
+func (m Message) IsIntentionallyEmpty() bool {
+	return m.Text == "" && Progress(m.EffectiveState()) >= Progress(StateTranslated)
+}
+
+// Decode reads the messages of a YAML file.
+// A message is either a string or a mapping with msg, desc, state, srchash and oldsrchash:
+//
+//	hello: hallo
+//	world:
+//	  msg: wereld
+//	  desc: the planet
+//	  state: approved
+//	  srchash: 8d969eef6ecad3c2
+//	  oldsrchash: 5e884898da280471
+func Decode(r io.Reader) ([]Message, error) {
+	var node yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&node); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil // empty file
+		}
+		return nil, err
+	}
+	if len(node.Content) == 0 {
+		return nil, nil
+	}
+	var messages []Message
+	for i, content := range node.Content[0].Content {
+		// is key?
+		if i%2 == 0 {
+			keyNode := content
+			valueNode := node.Content[0].Content[i+1]
+			if valueNode.Tag == "!!str" {
+				messages = append(messages, Message{Key: keyNode.Value, Text: valueNode.Value, Comment: keyNode.HeadComment})
+			} else if valueNode.Tag == "!!map" {
+				msg := Message{Key: keyNode.Value, Comment: keyNode.HeadComment}
+				for j, each := range valueNode.Content {
+					if j%2 == 0 {
+						mapkeyNode := each
+						mapvalueNode := valueNode.Content[j+1]
+						if mapkeyNode.Value == "msg" {
+							msg.Text = mapvalueNode.Value
+						}
+						if mapkeyNode.Value == "desc" {
+							msg.Description = mapvalueNode.Value
+						}
+						if mapkeyNode.Value == "state" {
+							msg.State = mapvalueNode.Value
+						}
+						if mapkeyNode.Value == "srchash" {
+							msg.SourceHash = mapvalueNode.Value
+						}
+						if mapkeyNode.Value == "oldsrchash" {
+							msg.OldSourceHash = mapvalueNode.Value
+						}
+					}
+				}
+				if msg.State != "" && Progress(msg.State) < 0 {
+					return nil, fmt.Errorf("unknown state %q of message %s", msg.State, msg.Key)
+				}
+				messages = append(messages, msg)
+			}
+		}
+	}
+	return messages, nil
+}
@@ -0,0 +1,78 @@
+// Package pseudo transforms texts for pseudo-localization.
+// It is shared by the PseudoLocalizer and the nls tool that generates a pseudo language.
+package pseudo
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// accents has an accented variant of each ASCII letter.
+var accents = map[rune]rune{
+	'a': 'á', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î', 'j': 'ĵ', 'k': 'ķ', 'l': 'ļ', 'm': 'ɱ',
+	'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ', 'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ',
+	'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ', 'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+// Accent returns the text with its ASCII letters replaced by accented ones.
+func Accent(s string) string {
+	return strings.Map(func(r rune) rune {
+		if a, ok := accents[r]; ok {
+			return a
+		}
+		return r
+	}, s)
+}
+
+// Options control how a text is wrapped.
+type Options struct {
+	// Expansion is the fraction of characters added to the text, e.g. 0.35 for 35%.
+	Expansion float64
+	// RTL wraps the text in right-to-left override marks such that it is displayed mirrored.
+	RTL bool
+}
+
+const padding = "~"
+
+// Wrap returns the text within brackets, padded for the expansion of size characters.
+func (o Options) Wrap(s string, size int) string {
+	b := new(strings.Builder)
+	if o.RTL {
+		b.WriteRune('\u202e') // right-to-left override
+	}
+	b.WriteString("[")
+	b.WriteString(s)
+	if n := int(float64(size)*o.Expansion + 0.5); n > 0 {
+		b.WriteString(" ")
+		b.WriteString(strings.Repeat(padding, n))
+	}
+	b.WriteString("]")
+	if o.RTL {
+		b.WriteRune('\u202c') // pop directional formatting
+	}
+	return b.String()
+}
+
+// Text returns the accented and wrapped text except for the parts to keep,
+// such as substituted values, which are left intact.
+func (o Options) Text(s string, keep ...string) string {
+	b := new(strings.Builder)
+	size := 0
+	for len(s) > 0 {
+		at, length := len(s), 0
+		for _, each := range keep {
+			if each == "" {
+				continue
+			}
+			if i := strings.Index(s, each); i >= 0 && (i < at || i == at && len(each) > length) {
+				at, length = i, len(each)
+			}
+		}
+		b.WriteString(Accent(s[:at]))
+		size += utf8.RuneCountInString(s[:at])
+		b.WriteString(s[at : at+length])
+		s = s[at+length:]
+	}
+	return o.Wrap(b.String(), size)
+}
@@ -0,0 +1,82 @@
+package nls
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"text/template"
+
+	"github.com/emicklei/nls/internal/messagefile"
+)
+
+// LoadCatalog reads the YAML and Fluent message files in the language directories of dir,
+// e.g. messages/nl/messages.yaml and messages/nl/app.ftl, and returns a catalog for NewLocalizer.
+// The files are read the same way as the nls tool does.
+// It returns an error for each message that is not a valid template.
+func LoadCatalog(fsys fs.FS, dir string) (TemplateMap, error) {
+	langDirs, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	catalog := TemplateMap{}
+	errs := []error{}
+	for _, each := range langDirs {
+		if !each.IsDir() {
+			continue
+		}
+		lang := each.Name()
+		files, err := fs.ReadDir(fsys, path.Join(dir, lang))
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range files {
+			var decode func(io.Reader) ([]messagefile.Message, error)
+			switch path.Ext(info.Name()) {
+			case ".yaml":
+				decode = messagefile.Decode
+			case ".ftl":
+				decode = messagefile.DecodeFluent
+			default:
+				continue
+			}
+			fileName := path.Join(dir, lang, info.Name())
+			file, err := fsys.Open(fileName)
+			if err != nil {
+				return nil, err
+			}
+			messages, err := decode(file)
+			file.Close()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", fileName, err))
+				continue
+			}
+			for _, msg := range messages {
+				if msg.Text == "" && !msg.IsIntentionallyEmpty() {
+					continue
+				}
+				mapkey := lang + "." + msg.Key
+				tmpl, err := template.New(mapkey).Parse(msg.Text)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", fileName, err))
+					continue
+				}
+				catalog[mapkey] = tmpl
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return catalog, nil
+}
+
+// LoadTable is like LoadCatalog but returns a Table for NewTableLocalizer.
+func LoadTable(fsys fs.FS, dir string) (*Table, error) {
+	catalog, err := LoadCatalog(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return tableFromMap(catalog), nil
+}
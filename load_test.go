@@ -0,0 +1,55 @@
+package nls
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	fsys := fstest.MapFS{
+		"messages/en/messages.yaml": {Data: []byte("hello: hello\nsea: '{{.color}} sea'\n")},
+		"messages/nl/messages.yaml": {Data: []byte("hello:\n  msg: hallo\n  desc: greeting\nsky:\nsuffix:\n  msg:\n  state: translated\n")},
+		"messages/nl/empty.yaml":    {Data: []byte("")},
+		"messages/nl/app.ftl":       {Data: []byte("-brand = Acme\nwelcome = Welkom bij { -brand }, { $name }!\n")},
+		"messages/README.md":        {Data: []byte("not a language")},
+	}
+	catalog, err := LoadCatalog(fsys, "messages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := NewLocalizer(catalog, "nl", "en")
+	if got, want := l.Get("hello"), "hallo"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Format("sea", "color", "blue"), "blue sea"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	// compiled the same way as by the nls tool
+	if got, want := l.Format("welcome", "name", "Sam"), "Welkom bij Acme, Sam!"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	// intentionally empty
+	if _, ok := catalog.Lookup("nl", "suffix"); !ok {
+		t.Error("missing empty message")
+	}
+	if _, ok := catalog.Lookup("nl", "sky"); ok {
+		t.Error("unexpected untranslated message")
+	}
+	table, err := LoadTable(fsys, "messages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := NewTableLocalizer(table, "nl").Get("hello"), "hallo"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	fsys["messages/en/bad.yaml"] = &fstest.MapFile{Data: []byte("bad: '{{.x'\n")}
+	if _, err := LoadCatalog(fsys, "messages"); err == nil || !strings.Contains(err.Error(), "bad.yaml") {
+		t.Errorf("expected parse error, got [%v]", err)
+	}
+	delete(fsys, "messages/en/bad.yaml")
+	fsys["messages/en/bad.ftl"] = &fstest.MapFile{Data: []byte("bad = { unknown }\n")}
+	if _, err := LoadCatalog(fsys, "messages"); err == nil || !strings.Contains(err.Error(), "bad.ftl") {
+		t.Errorf("expected compile error, got [%v]", err)
+	}
+}
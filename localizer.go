@@ -1,8 +1,7 @@
 package nls
 
 import (
-	"bytes"
-	"fmt"
+	"strings"
 	"text/template"
 
 	"golang.org/x/text/language"
@@ -21,32 +20,108 @@ type Localizer interface {
 }
 
 type localizer struct {
-	catalog   map[string]*template.Template
-	languages []string // at least one language is present
+	catalog   Catalog
+	table     *Table        // if the catalog is a Table
+	languages []string      // at least one language is present
+	content   *tableContent // of the table when the chain was resolved
+	chain     [][]entry     // messages of the content for each of the languages
 }
 
+// NewLocalizer returns a Localizer for a map with language-key keys, e.g. "en.hello".
+// Messages are looked up in the map itself; use NewTableLocalizer for lookups without string concatenation.
 func NewLocalizer(catalog map[string]*template.Template, languages ...string) Localizer {
+	return NewCatalogLocalizer(TemplateMap(catalog), languages...)
+}
+
+// NewCatalogLocalizer returns a Localizer for a Catalog with zero or more languages.
+func NewCatalogLocalizer(catalog Catalog, languages ...string) Localizer {
+	switch c := catalog.(type) {
+	case nil:
+		catalog = TemplateMap(nil)
+	case *Table:
+		return NewTableLocalizer(c, languages...)
+	}
+	return localizer{catalog: catalog, languages: withDefaultLanguage(languages)}
+}
+
+// NewTableLocalizer returns a Localizer for a Table with zero or more languages.
+func NewTableLocalizer(table *Table, languages ...string) Localizer {
+	languages = withDefaultLanguage(languages)
+	content := table.content.Load()
+	chain := [][]entry{}
+	for _, each := range languages {
+		if messages, ok := content.languages[each]; ok {
+			chain = append(chain, messages)
+		}
+	}
+	return localizer{catalog: table, table: table, languages: languages, content: content, chain: chain}
+}
+
+// withDefaultLanguage returns the languages, each regional one followed by its base languages (en-GB, en),
+// or English if there are none.
+func withDefaultLanguage(languages []string) []string {
 	if len(languages) == 0 {
-		languages = append(languages, language.English.String())
+		return []string{language.English.String()}
 	}
-	return localizer{catalog: catalog, languages: languages}
+	expanded := make([]string, 0, len(languages))
+	seen := map[string]bool{}
+	for _, each := range languages {
+		for lang := each; lang != ""; {
+			if !seen[lang] {
+				seen[lang] = true
+				expanded = append(expanded, lang)
+			}
+			i := strings.LastIndex(lang, "-")
+			if i < 0 {
+				break
+			}
+			lang = lang[:i]
+		}
+	}
+	return expanded
 }
 
-func (l localizer) findTemplate(key string) *template.Template {
-	for _, lang := range l.languages {
-		mapkey := fmt.Sprintf("%s.%s", lang, key)
-		if tmpl, ok := l.catalog[mapkey]; ok {
-			return tmpl
+func (l localizer) findMessage(key string) (entry, bool) {
+	if l.table == nil {
+		for _, each := range l.languages {
+			if tmpl, ok := l.catalog.Lookup(each, key); ok {
+				if text, ok := staticTexts.Load(tmpl); ok {
+					return entry{tmpl: tmpl, text: text.(string), static: true}, true
+				}
+				return entry{tmpl: tmpl}, true
+			}
+		}
+		return entry{}, false
+	}
+	content := l.table.content.Load()
+	id, ok := content.ids[key]
+	if !ok {
+		return entry{}, false
+	}
+	if content == l.content {
+		for _, messages := range l.chain {
+			if m := &messages[id]; m.present() {
+				return *m, true
+			}
+		}
+		return entry{}, false
+	}
+	// the content was replaced after the chain was resolved
+	for _, each := range l.languages {
+		if messages, ok := content.languages[each]; ok {
+			if m := &messages[id]; m.present() {
+				return *m, true
+			}
 		}
 	}
-	return nil
+	return entry{}, false
 }
 
 // Get returns the text associated with a key for using the available languages
 // It returns an empty string if none of the languages have a (non-empty) value for the key and no fallback is provided.
 func (l localizer) Get(key string, fallback ...string) string {
-	tmpl := l.findTemplate(key)
-	if tmpl == nil {
+	m, ok := l.findMessage(key)
+	if !ok {
 		if len(fallback) > 0 {
 			addMissing(l.languages[0], key, fallback[0])
 			return fallback[0]
@@ -54,10 +129,8 @@ func (l localizer) Get(key string, fallback ...string) string {
 		addMissing(l.languages[0], key, "")
 		return key
 	}
-	buf := new(bytes.Buffer)
 	// execute with no data
-	_ = tmpl.Execute(buf, nil)
-	msg := buf.String()
+	msg, _ := m.execute(nil)
 	if msg == "" {
 		if len(fallback) > 0 {
 			addMissing(l.languages[0], key, fallback[0])
@@ -86,17 +159,17 @@ func (l localizer) Format(key string, kv ...any) string {
 // Replaced returns the text after applying substitutions using the replacements.
 // Returns an empty string if there no such key.
 func (l localizer) Replaced(key string, replacements ...map[string]any) string {
-	tmpl := l.findTemplate(key)
-	if tmpl == nil {
+	m, ok := l.findMessage(key)
+	if !ok {
 		return ""
 	}
 	var data any
 	if len(replacements) > 0 {
 		data = replacements[0]
 	}
-	buf := new(bytes.Buffer)
-	if err := tmpl.Execute(buf, data); err != nil {
+	msg, err := m.execute(data)
+	if err != nil {
 		return err.Error()
 	}
-	return buf.String()
+	return msg
 }
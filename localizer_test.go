@@ -159,3 +159,22 @@ func TestExecTemplateError(t *testing.T) {
 	l := NewLocalizer(cat, "en")
 	l.Replaced("bad", map[string]any{"A": []string{}}) // should not panic
 }
+
+func TestRegionalFallback(t *testing.T) {
+	cat := map[string]*template.Template{
+		"en.color":    mustTemplate("color"),
+		"en.hello":    mustTemplate("hello"),
+		"en-GB.color": mustTemplate("colour"),
+		"nl.hello":    mustTemplate("hallo"),
+	}
+	l := NewLocalizer(cat, "en-GB", "nl")
+	if got, want := strings.Join(l.(localizer).languages, ","), "en-GB,en,nl"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Get("color"), "colour"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Get("hello"), "hello"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+}
@@ -3,6 +3,7 @@ package nls
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"text/template"
 )
 
@@ -35,7 +36,16 @@ func ReportMissing() string {
 	return report.String()
 }
 
-// Register is called from generated code.
-func Register(catalog map[string]*template.Template, key string, templateSource string) {
-	catalog[key] = template.Must(template.New(key).Parse(templateSource))
+// Register parses the template source of a message with a language-key key, e.g. "en.hello", into the catalog.
+// It is called from code generated by earlier versions of the nls tool.
+// The text of a template without actions is kept such that a Localizer returns it without executing the template.
+func Register(catalog TemplateMap, key string, templateSource string) {
+	tmpl := template.Must(template.New(key).Parse(templateSource))
+	if m := newEntry(tmpl); m.static {
+		staticTexts.Store(tmpl, m.text)
+	}
+	catalog[key] = tmpl
 }
+
+// staticTexts holds the text of each template registered with Register that has no actions.
+var staticTexts sync.Map // *template.Template -> string
@@ -0,0 +1,96 @@
+// Package nlstest has helpers for testing the messages of a catalog, such as the one of a generated package.
+//
+//	func TestMessages(t *testing.T) {
+//		nlstest.Golden(t, msg.Catalog(), map[string]map[string]any{
+//			msg.M_cats: {"count": 2},
+//		})
+//	}
+//
+// To write the golden files, set Update, e.g. from a flag of the test package:
+//
+//	func init() {
+//		flag.BoolVar(&nlstest.Update, "update", false, "update the golden files")
+//	}
+package nlstest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/emicklei/nls"
+)
+
+// Update makes Golden write the golden files instead of comparing with them.
+var Update bool
+
+// Render executes the template of every key in every language of the catalog
+// with the example parameters of the key and returns the results, sorted by language and key.
+// It returns an error for each template that fails to execute.
+func Render(catalog nls.Catalog, examples map[string]map[string]any) (string, error) {
+	b := new(strings.Builder)
+	errs := []error{}
+	for _, lang := range catalog.Languages() {
+		for _, key := range catalog.Keys() {
+			tmpl, ok := catalog.Lookup(lang, key)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(b, "== %s.%s\n", lang, key)
+			if err := tmpl.Execute(b, examples[key]); err != nil {
+				errs = append(errs, fmt.Errorf("%s.%s: %w", lang, key, err))
+			}
+			fmt.Fprintln(b)
+		}
+	}
+	return b.String(), errors.Join(errs...)
+}
+
+// Golden renders the messages of the catalog (see Render) and compares the result
+// with the golden file testdata/<name of the test>.golden. With Update, the golden file is written.
+// The test fails if a template fails to execute.
+func Golden(t testing.TB, catalog nls.Catalog, examples map[string]map[string]any) {
+	t.Helper()
+	rendered, err := Render(catalog, examples)
+	if err != nil {
+		t.Fatalf("cannot render messages:\n%v", err)
+	}
+	fileName := filepath.Join("testdata", strings.ReplaceAll(t.Name(), "/", "_")+".golden")
+	if Update {
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fileName, []byte(rendered), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	golden, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("cannot read golden file, set nlstest.Update: %v", err)
+	}
+	if got, want := rendered, string(golden); got != want {
+		t.Errorf("messages differ from %s, set nlstest.Update to accept:\n%s", fileName, firstDifference(got, want))
+	}
+}
+
+// firstDifference returns the first line that differs.
+func firstDifference(got, want string) string {
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(want, "\n")
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var g, w string
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if g != w {
+			return fmt.Sprintf("line %d\n got: %s\nwant: %s", i+1, g, w)
+		}
+	}
+	return ""
+}
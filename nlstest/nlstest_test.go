@@ -0,0 +1,75 @@
+package nlstest
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/emicklei/nls"
+)
+
+func init() {
+	flag.BoolVar(&Update, "update", false, "update the golden files")
+}
+
+func catalog() nls.TemplateMap {
+	return nls.TemplateMap{
+		"en.cats":      template.Must(template.New("").Parse("{{.count}} {{- if gt .count 1}} cats{{- else}} cat{{- end}}")),
+		"nl.cats":      template.Must(template.New("").Parse("{{.count}} {{- if gt .count 1}} katten{{- else}} kat{{- end}}")),
+		"en.hello":     template.Must(template.New("").Parse("hello")),
+		"en-GB.colour": template.Must(template.New("").Parse("colour")),
+	}
+}
+
+func TestGolden(t *testing.T) {
+	Golden(t, catalog(), map[string]map[string]any{
+		"cats": {"count": 2},
+	})
+}
+
+func TestRenderError(t *testing.T) {
+	_, err := Render(catalog(), nil)
+	if err == nil || !strings.Contains(err.Error(), "nl.cats") {
+		t.Errorf("expected execution error, got [%v]", err)
+	}
+}
+
+// recorder records the failure instead of stopping the test.
+type recorder struct {
+	testing.TB
+	failure string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.failure = fmt.Sprintf(format, args...)
+}
+
+func TestStrict(t *testing.T) {
+	r := new(recorder)
+	l := Strict(r, catalog(), "en-GB", "nl")
+	if got, want := l.Get("colour"), "colour"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Get("hello"), "hello"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Format("cats", "count", 1), "1 cat"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if r.failure != "" {
+		t.Fatalf("unexpected failure: %s", r.failure)
+	}
+	l.Get("unknown", "fallback")
+	if !strings.Contains(r.failure, "missing message") {
+		t.Errorf("expected missing message failure, got [%s]", r.failure)
+	}
+	r.failure = ""
+	l.Replaced("cats")
+	if !strings.Contains(r.failure, "cannot execute") {
+		t.Errorf("expected execution failure, got [%s]", r.failure)
+	}
+}
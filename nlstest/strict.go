@@ -0,0 +1,88 @@
+package nlstest
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/emicklei/nls"
+)
+
+// strictLocalizer fails a test on a missing key or a template that fails to execute.
+type strictLocalizer struct {
+	t         testing.TB
+	catalog   nls.Catalog
+	languages []string
+}
+
+// Strict returns a Localizer for the catalog that calls t.Fatalf if none of the languages
+// (nor their base languages, e.g. en for en-GB) has a message for a key, or if its template fails to execute.
+// Like t.Fatalf, it must be used from the goroutine running the test.
+func Strict(t testing.TB, catalog nls.Catalog, languages ...string) nls.Localizer {
+	if len(languages) == 0 {
+		languages = []string{"en"}
+	}
+	return strictLocalizer{t: t, catalog: catalog, languages: languages}
+}
+
+func (s strictLocalizer) lookup(key string) *template.Template {
+	for _, each := range s.languages {
+		for lang := each; ; {
+			if tmpl, ok := s.catalog.Lookup(lang, key); ok {
+				return tmpl
+			}
+			i := strings.LastIndex(lang, "-")
+			if i < 0 {
+				break
+			}
+			lang = lang[:i]
+		}
+	}
+	s.t.Helper()
+	s.t.Fatalf("missing message %q in %v", key, s.languages)
+	return nil
+}
+
+func (s strictLocalizer) execute(key string, data any) string {
+	s.t.Helper()
+	tmpl := s.lookup(key)
+	if tmpl == nil {
+		return ""
+	}
+	b := new(strings.Builder)
+	if err := tmpl.Execute(b, data); err != nil {
+		s.t.Fatalf("cannot execute message %q: %v", key, err)
+	}
+	return b.String()
+}
+
+// Get returns the text of a key; the fallback is not used.
+func (s strictLocalizer) Get(key string, fallback ...string) string {
+	s.t.Helper()
+	return s.execute(key, nil)
+}
+
+// Format returns the text after applying substitutions using the key(string) and value pairs.
+func (s strictLocalizer) Format(key string, kv ...any) string {
+	s.t.Helper()
+	params := map[string]any{}
+	for i := 0; i < len(kv); i += 2 {
+		k, ok := kv[i].(string)
+		if !ok || i+1 == len(kv) {
+			s.t.Fatalf("bad arguments for message %q: Format expects [string,any] pairs", key)
+			return ""
+		}
+		params[k] = kv[i+1]
+	}
+	return s.execute(key, params)
+}
+
+// Replaced returns the text after applying substitutions using the replacements.
+func (s strictLocalizer) Replaced(key string, replacements ...map[string]any) string {
+	s.t.Helper()
+	var data any
+	if len(replacements) > 0 {
+		data = replacements[0]
+	}
+	return s.execute(key, data)
+}
@@ -0,0 +1,84 @@
+package nls
+
+import (
+	"errors"
+	"sort"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+)
+
+var _ Localizer = PrinterLocalizer{}
+
+// PrinterLocalizer is a Localizer that uses a golang.org/x/text/message Printer for the messages of a Catalog,
+// e.g. extracted by gotext. Named values are passed to the Printer
+// as positional arguments in sorted order of their names.
+type PrinterLocalizer struct {
+	Printer *message.Printer
+	Catalog catalog.Catalog // of the Printer
+	Tag     language.Tag    // of the Printer
+}
+
+// NewPrinterLocalizer returns a Localizer for the messages of a catalog in a language.
+func NewPrinterLocalizer(cat catalog.Catalog, tag language.Tag) Localizer {
+	return PrinterLocalizer{Printer: message.NewPrinter(tag, message.Catalog(cat)), Catalog: cat, Tag: tag}
+}
+
+// discard renders a message of a catalog to nothing.
+type discard struct{}
+
+func (discard) Render(string) {}
+func (discard) Arg(int) any   { return nil }
+
+// has returns whether the catalog has a message for the key in the language (or a parent language).
+// Keys of gotext-extracted messages are their source texts, so the key cannot be compared with the text.
+func (p PrinterLocalizer) has(key string) bool {
+	err := p.Catalog.Context(p.Tag, discard{}).Execute(key)
+	return !errors.Is(err, catalog.ErrNotFound)
+}
+
+// Get returns the text associated with a key.
+// It returns the first fallback or else the key if the catalog has no message for the key.
+func (p PrinterLocalizer) Get(key string, fallback ...string) string {
+	if !p.has(key) && len(fallback) > 0 {
+		return fallback[0]
+	}
+	return p.Printer.Sprintf(key)
+}
+
+// Format returns the text after applying substitutions using the key(string) and value pairs.
+// Returns an empty string if there no such key.
+func (p PrinterLocalizer) Format(key string, kv ...any) string {
+	params := map[string]any{}
+	for i := 0; i < len(kv); i += 2 {
+		k := kv[i]
+		if ks, ok := k.(string); ok {
+			params[ks] = kv[i+1]
+		} else {
+			return "bad arguments: Format expects [string,any] pairs"
+		}
+	}
+	return p.Replaced(key, params)
+}
+
+// Replaced returns the text after applying substitutions using the replacements.
+// Returns an empty string if there no such key.
+func (p PrinterLocalizer) Replaced(key string, replacements ...map[string]any) string {
+	if !p.has(key) {
+		return ""
+	}
+	if len(replacements) == 0 {
+		return p.Printer.Sprintf(key)
+	}
+	names := []string{}
+	for name := range replacements[0] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args := []any{}
+	for _, each := range names {
+		args = append(args, replacements[0][each])
+	}
+	return p.Printer.Sprintf(key, args...)
+}
@@ -0,0 +1,35 @@
+package nls
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message/catalog"
+)
+
+func TestPrinterLocalizer(t *testing.T) {
+	b := catalog.NewBuilder()
+	b.SetString(language.Dutch, "hello", "hallo")
+	b.SetString(language.Dutch, "sea", "%[2]v %[1]v")
+	b.SetString(language.Dutch, "Save", "Save")
+	l := NewPrinterLocalizer(b, language.Dutch)
+	if got, want := l.Get("hello"), "hallo"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Get("unknown", "fallback"), "fallback"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Format("sea", "name", "Noord", "color", "blauwe"), "Noord blauwe"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Replaced("unknown", map[string]any{"name": "Noord"}), ""; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	// the translation equals the key
+	if got, want := l.Get("Save", "fallback"), "Save"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Replaced("Save", map[string]any{}), "Save"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+}
@@ -0,0 +1,62 @@
+package nls
+
+import (
+	"fmt"
+
+	"github.com/emicklei/nls/internal/pseudo"
+)
+
+// PseudoLocalizer is a Localizer that pseudo-localizes the texts of another Localizer, for testing user interfaces.
+// A text gets accented characters, brackets and extra characters, e.g. "Hello" becomes "[Ĥéļļö ~~]",
+// such that hard-coded texts, truncation and encoding problems are easy to spot.
+// Substituted values are left intact.
+type PseudoLocalizer struct {
+	Localizer Localizer
+	// Expansion is the fraction of characters added to a text, e.g. 0.35 for 35%.
+	Expansion float64
+	// RTL displays the texts mirrored using right-to-left override marks.
+	RTL bool
+}
+
+// NewPseudoLocalizer returns a PseudoLocalizer for a Localizer with an expansion of 35%.
+func NewPseudoLocalizer(localizer Localizer) PseudoLocalizer {
+	return PseudoLocalizer{Localizer: localizer, Expansion: 0.35}
+}
+
+func (p PseudoLocalizer) options() pseudo.Options {
+	return pseudo.Options{Expansion: p.Expansion, RTL: p.RTL}
+}
+
+// Get returns the pseudo-localized text associated with a key.
+func (p PseudoLocalizer) Get(key string, fallback ...string) string {
+	return p.pseudo(p.Localizer.Get(key, fallback...), nil)
+}
+
+// Format returns the pseudo-localized text after applying substitutions using the key(string) and value pairs.
+// Returns an empty string if there no such key.
+func (p PseudoLocalizer) Format(key string, kv ...any) string {
+	values := []string{}
+	for i := 1; i < len(kv); i += 2 {
+		values = append(values, fmt.Sprint(kv[i]))
+	}
+	return p.pseudo(p.Localizer.Format(key, kv...), values)
+}
+
+// Replaced returns the pseudo-localized text after applying substitutions using the replacements.
+// Returns an empty string if there no such key.
+func (p PseudoLocalizer) Replaced(key string, replacements ...map[string]any) string {
+	values := []string{}
+	for _, each := range replacements {
+		for _, v := range each {
+			values = append(values, fmt.Sprint(v))
+		}
+	}
+	return p.pseudo(p.Localizer.Replaced(key, replacements...), values)
+}
+
+func (p PseudoLocalizer) pseudo(text string, values []string) string {
+	if text == "" {
+		return ""
+	}
+	return p.options().Text(text, values...)
+}
@@ -0,0 +1,31 @@
+package nls
+
+import (
+	"testing"
+)
+
+func TestPseudoLocalizer(t *testing.T) {
+	cat := TemplateMap{
+		"en.hello": mustTemplate("Hello"),
+		"en.sea":   mustTemplate("{{.color}} sea of {{.name}}"),
+		"en.empty": mustTemplate(""),
+	}
+	l := NewPseudoLocalizer(NewLocalizer(cat, "en"))
+	if got, want := l.Get("hello"), "[Ĥéļļö ~~]"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Format("sea", "color", "blue", "name", "Sam"), "[blue šéá öƒ Sam ~~~]"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Replaced("sea", map[string]any{"color": 42, "name": "Ed"}), "[42 šéá öƒ Ed ~~~]"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Get("empty"), ""; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	l.RTL = true
+	l.Expansion = 0
+	if got, want := l.Get("hello"), "\u202e[Ĥéļļö]\u202c"; got != want {
+		t.Errorf("got [%q] want [%q]", got, want)
+	}
+}
@@ -0,0 +1,204 @@
+package nls
+
+import (
+	"bytes"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"text/template/parse"
+)
+
+// Table is a Catalog of message templates in which each key has a dense integer ID.
+// Per language, the messages are stored in a slice indexed by ID such that
+// a lookup needs no string concatenation nor allocation.
+// The content of a Table can be replaced, e.g. when reloading message files, while Localizers use it.
+type Table struct {
+	content atomic.Pointer[tableContent]
+}
+
+type tableContent struct {
+	keys      []string // indexed by ID
+	ids       map[string]int
+	languages map[string][]entry
+}
+
+// entry is a template or, if the template is plain text, its precomputed text.
+type entry struct {
+	tmpl   *template.Template // nil if absent or lazy
+	lazy   *lazyTemplate
+	text   string
+	static bool
+}
+
+// lazyTemplate is parsed on first use.
+type lazyTemplate struct {
+	once   sync.Once
+	name   string
+	source string
+	plain  bool // the source is text that is not parsed
+	tmpl   *template.Template
+}
+
+func (l *lazyTemplate) template() *template.Template {
+	l.once.Do(func() {
+		if l.plain {
+			l.tmpl = textTemplate(l.name, l.source)
+			return
+		}
+		l.tmpl = template.Must(template.New(l.name).Parse(l.source))
+	})
+	return l.tmpl
+}
+
+// textTemplate returns a template that outputs the text as is, even if it contains delimiters.
+func textTemplate(name, text string) *template.Template {
+	root := &parse.ListNode{NodeType: parse.NodeList}
+	if text != "" {
+		root.Nodes = append(root.Nodes, &parse.TextNode{NodeType: parse.NodeText, Text: []byte(text)})
+	}
+	return template.Must(template.New(name).AddParseTree(name, &parse.Tree{Name: name, Root: root}))
+}
+
+// present returns whether the entry has a message.
+func (m *entry) present() bool {
+	return m.tmpl != nil || m.lazy != nil
+}
+
+// template returns the parsed template of the entry.
+func (m *entry) template() *template.Template {
+	if m.lazy != nil {
+		return m.lazy.template()
+	}
+	return m.tmpl
+}
+
+var bufferPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// execute returns the precomputed text or the result of executing the template with the data.
+func (m *entry) execute(data any) (string, error) {
+	if m.static {
+		return m.text, nil
+	}
+	tmpl := m.template()
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer bufferPool.Put(buf)
+	err := tmpl.Execute(buf, data)
+	return buf.String(), err
+}
+
+// newEntry returns an entry that is static if the template has only text nodes.
+func newEntry(tmpl *template.Template) entry {
+	m := entry{tmpl: tmpl, static: true}
+	if tmpl.Tree == nil {
+		return m
+	}
+	b := new(strings.Builder)
+	for _, each := range tmpl.Tree.Root.Nodes {
+		text, ok := each.(*parse.TextNode)
+		if !ok {
+			return entry{tmpl: tmpl}
+		}
+		b.Write(text.Text)
+	}
+	m.text = b.String()
+	return m
+}
+
+// NewTable returns a Table for the keys; the ID of a key is its index.
+func NewTable(keys ...string) *Table {
+	ids := make(map[string]int, len(keys))
+	for i, each := range keys {
+		ids[each] = i
+	}
+	t := new(Table)
+	t.content.Store(&tableContent{keys: keys, ids: ids, languages: map[string][]entry{}})
+	return t
+}
+
+// ID returns the message ID of a key.
+func (t *Table) ID(key string) (int, bool) {
+	id, ok := t.content.Load().ids[key]
+	return id, ok
+}
+
+// Replace atomically replaces the content of the Table by that of another,
+// such that existing Localizers of the Table use the messages of the other.
+func (t *Table) Replace(other *Table) {
+	t.content.Store(other.content.Load())
+}
+
+// Register parses the template source of a message in a language. It is called from generated code.
+// All messages must be registered before a Localizer is created for the Table.
+func (t *Table) Register(id int, lang string, templateSource string) {
+	c := t.content.Load()
+	c.set(id, lang, newEntry(template.Must(template.New(lang+"."+c.keys[id]).Parse(templateSource))))
+}
+
+// RegisterLazy stores the template source of a message in a language which is parsed on first use.
+// It is called from generated code for which the source is known to parse without errors.
+func (t *Table) RegisterLazy(id int, lang string, templateSource string) {
+	c := t.content.Load()
+	c.set(id, lang, entry{lazy: &lazyTemplate{name: lang + "." + c.keys[id], source: templateSource}})
+}
+
+// RegisterText stores a message in a language that has no template actions. It is called from generated code.
+func (t *Table) RegisterText(id int, lang string, text string) {
+	c := t.content.Load()
+	c.set(id, lang, entry{lazy: &lazyTemplate{name: lang + "." + c.keys[id], source: text, plain: true}, text: text, static: true})
+}
+
+// Lookup returns the template of the message for a key in a language.
+func (t *Table) Lookup(lang, key string) (*template.Template, bool) {
+	c := t.content.Load()
+	id, ok := c.ids[key]
+	if !ok {
+		return nil, false
+	}
+	entries, ok := c.languages[lang]
+	if !ok || !entries[id].present() {
+		return nil, false
+	}
+	return entries[id].template(), true
+}
+
+// Languages returns the languages that have messages, sorted.
+func (t *Table) Languages() []string {
+	c := t.content.Load()
+	list := make([]string, 0, len(c.languages))
+	for each := range c.languages {
+		list = append(list, each)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Keys returns the keys of all messages, sorted.
+func (t *Table) Keys() []string {
+	keys := slices.Clone(t.content.Load().keys)
+	sort.Strings(keys)
+	return keys
+}
+
+func (c *tableContent) set(id int, lang string, m entry) {
+	entries, ok := c.languages[lang]
+	if !ok {
+		entries = make([]entry, len(c.ids))
+		c.languages[lang] = entries
+	}
+	entries[id] = m
+}
+
+// tableFromMap returns a Table with the templates of a map with language-key keys, e.g. "en.hello".
+func tableFromMap(catalog TemplateMap) *Table {
+	t := NewTable(catalog.Keys()...)
+	c := t.content.Load()
+	for mapkey, tmpl := range catalog {
+		lang, key, _ := strings.Cut(mapkey, ".")
+		c.set(c.ids[key], lang, newEntry(tmpl))
+	}
+	return t
+}
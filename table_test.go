@@ -0,0 +1,128 @@
+package nls
+
+import (
+	"testing"
+	"text/template"
+)
+
+func TestTable(t *testing.T) {
+	table := NewTable("hello", "world")
+	table.Register(0, "en", "hello")
+	table.Register(1, "en", "world")
+	table.Register(0, "nl", "hallo")
+	if id, ok := table.ID("world"); !ok || id != 1 {
+		t.Errorf("got [%d,%v] want [1,true]", id, ok)
+	}
+	l := NewTableLocalizer(table, "fr", "nl", "en")
+	if got, want := l.Get("hello"), "hallo"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Get("world"), "world"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Get("unknown", "fallback"), "fallback"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+}
+
+func TestStaticMessage(t *testing.T) {
+	table := NewTable("hello", "sea", "plain")
+	table.Register(0, "en", "hello {{- ` world`}}")
+	table.Register(1, "en", "{{.color}} sea")
+	table.RegisterText(2, "en", "no {{template}}")
+	if m := table.content.Load().languages["en"][0]; m.static {
+		t.Error("message with action must not be static")
+	}
+	if m := table.content.Load().languages["en"][2]; !m.static {
+		t.Error("registered text must be static")
+	}
+	l := NewTableLocalizer(table, "en")
+	if got, want := l.Get("plain"), "no {{template}}"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Format("sea", "color", "blue"), "blue sea"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	// looked up as a template
+	if got, want := NewCatalogLocalizer(Layers{table}, "en").Get("plain"), "no {{template}}"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	m := newEntry(mustTemplate("just text"))
+	if !m.static || m.text != "just text" {
+		t.Errorf("got [%v] want static text", m)
+	}
+}
+
+func TestLazyMessage(t *testing.T) {
+	table := NewTable("sea")
+	table.RegisterLazy(0, "en", "{{.color}} sea")
+	l := NewTableLocalizer(table, "en")
+	done := make(chan string)
+	for i := 0; i < 4; i++ {
+		go func() { done <- l.Format("sea", "color", "blue") }()
+	}
+	for i := 0; i < 4; i++ {
+		if got, want := <-done, "blue sea"; got != want {
+			t.Errorf("got [%s] want [%s]", got, want)
+		}
+	}
+}
+
+func benchmarkCatalog() map[string]*template.Template {
+	catalog := map[string]*template.Template{}
+	Register(catalog, "en.hello", "hello")
+	Register(catalog, "nl.hello", "hallo")
+	Register(catalog, "en.sea", "{{.color}} sea")
+	return catalog
+}
+
+func BenchmarkNewLocalizer(b *testing.B) {
+	catalog := benchmarkCatalog()
+	table := tableFromMap(catalog)
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			NewLocalizer(catalog, "nl", "en")
+		}
+	})
+	b.Run("table", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			NewTableLocalizer(table, "nl", "en")
+		}
+	})
+}
+
+func BenchmarkFindMessage(b *testing.B) {
+	l := NewTableLocalizer(tableFromMap(benchmarkCatalog()), "fr", "nl", "en").(localizer)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, ok := l.findMessage("sea"); !ok {
+			b.Fatal("missing template")
+		}
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	l := NewTableLocalizer(tableFromMap(benchmarkCatalog()), "nl", "en")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Get("hello")
+	}
+}
+
+func BenchmarkGetMap(b *testing.B) {
+	l := NewLocalizer(benchmarkCatalog(), "nl", "en")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Get("hello")
+	}
+}
+
+func BenchmarkFormat(b *testing.B) {
+	l := NewTableLocalizer(tableFromMap(benchmarkCatalog()), "nl", "en")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Format("sea", "color", "blue")
+	}
+}
@@ -0,0 +1,105 @@
+package nls
+
+import (
+	"context"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+)
+
+// Tenants has overlay catalogs of tenants, e.g. white-label customers, on top of a base catalog.
+// The overlay of a tenant only needs the messages that the tenant rewords.
+type Tenants struct {
+	base     Catalog
+	mutex    sync.RWMutex
+	overlays map[string]Catalog
+}
+
+// NewTenants returns Tenants without overlays for a base catalog.
+func NewTenants(base Catalog) *Tenants {
+	return &Tenants{base: base, overlays: map[string]Catalog{}}
+}
+
+// Add sets the overlay catalog of a tenant, replacing an existing one.
+func (t *Tenants) Add(tenant string, overlay Catalog) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.overlays[tenant] = overlay
+}
+
+// Load reads the overlay catalogs of all tenants from the subdirectories of dir,
+// one per tenant with the same layout as for LoadCatalog, e.g. tenants/acme/nl/messages.yaml.
+func (t *Tenants) Load(fsys fs.FS, dir string) error {
+	tenantDirs, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, each := range tenantDirs {
+		if !each.IsDir() {
+			continue
+		}
+		overlay, err := LoadCatalog(fsys, path.Join(dir, each.Name()))
+		if err != nil {
+			return err
+		}
+		t.Add(each.Name(), overlay)
+	}
+	return nil
+}
+
+// Catalog returns the catalog of a tenant, which is the base catalog if the tenant has no overlay.
+func (t *Tenants) Catalog(tenant string) Catalog {
+	t.mutex.RLock()
+	overlay, ok := t.overlays[tenant]
+	t.mutex.RUnlock()
+	if !ok {
+		return t.base
+	}
+	return Layers{overlay, t.base}
+}
+
+// Localizer returns a Localizer of a tenant with zero or more languages.
+func (t *Tenants) Localizer(tenant string, languages ...string) Localizer {
+	return NewCatalogLocalizer(t.Catalog(tenant), languages...)
+}
+
+// LocalizerFromContext returns a Localizer of the tenant of the context, see ContextWithTenant.
+func (t *Tenants) LocalizerFromContext(ctx context.Context, languages ...string) Localizer {
+	return t.Localizer(TenantFromContext(ctx), languages...)
+}
+
+// Overrides returns for each tenant the sorted keys of the base catalog that its overlay rewords.
+func (t *Tenants) Overrides() map[string][]string {
+	base := map[string]bool{}
+	for _, each := range t.base.Keys() {
+		base[each] = true
+	}
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	report := map[string][]string{}
+	for tenant, overlay := range t.overlays {
+		keys := []string{}
+		for _, each := range overlay.Keys() {
+			if base[each] {
+				keys = append(keys, each)
+			}
+		}
+		sort.Strings(keys)
+		report[tenant] = keys
+	}
+	return report
+}
+
+var tenantKey = struct{ tenantKey string }{}
+
+// ContextWithTenant returns a new context that holds the name of a tenant.
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenant)
+}
+
+// TenantFromContext returns the name of the tenant from the context or an empty string if absent.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey).(string)
+	return tenant
+}
@@ -0,0 +1,44 @@
+package nls
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTenants(t *testing.T) {
+	base := TemplateMap{
+		"en.project":  mustTemplate("project"),
+		"en.projects": mustTemplate("{{.count}} projects"),
+		"nl.project":  mustTemplate("project"),
+	}
+	tenants := NewTenants(base)
+	fsys := fstest.MapFS{
+		"tenants/acme/en/messages.yaml": {Data: []byte("project: workspace\nprojects: '{{.count}} workspaces'\nextra: more\n")},
+		"tenants/acme/nl/messages.yaml": {Data: []byte("project: werkruimte\n")},
+	}
+	if err := tenants.Load(fsys, "tenants"); err != nil {
+		t.Fatal(err)
+	}
+	tenants.Add("globex", TemplateMap{"en.project": mustTemplate("campaign")})
+
+	ctx := ContextWithTenant(context.Background(), "acme")
+	l := tenants.LocalizerFromContext(ctx, "nl", "en")
+	if got, want := l.Get("project"), "werkruimte"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := l.Format("projects", "count", 2), "2 workspaces"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := tenants.Localizer("unknown", "en").Get("project"), "project"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	report := tenants.Overrides()
+	if got, want := strings.Join(report["acme"], ","), "project,projects"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	if got, want := strings.Join(report["globex"], ","), "project"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+}
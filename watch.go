@@ -0,0 +1,79 @@
+package nls
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"strings"
+	"time"
+)
+
+// WatchTable reloads the table from the YAML and Fluent message files of the language directories of dir
+// when they change, checking every interval until the context is done. The table keeps its messages
+// until a file changes. Existing Localizers of the table use the reloaded messages. A reload that fails,
+// e.g. because of a template parse error, is logged and the table keeps its messages.
+// It is meant for development.
+func WatchTable(ctx context.Context, table *Table, fsys fs.FS, dir string, interval time.Duration) error {
+	if _, err := fs.ReadDir(fsys, dir); err != nil {
+		return err
+	}
+	last, err := fingerprint(fsys, dir)
+	if err != nil {
+		return err
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		changed := false
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			current, err := fingerprint(fsys, dir)
+			if err != nil {
+				log.Printf("nls: cannot check message files in %s: %v\n", dir, err)
+				continue
+			}
+			if current != last {
+				// wait until files are completely written
+				last, changed = current, true
+				continue
+			}
+			if !changed {
+				continue
+			}
+			changed = false
+			loaded, err := LoadTable(fsys, dir)
+			if err != nil {
+				log.Printf("nls: cannot reload message files in %s: %v\n", dir, err)
+				continue
+			}
+			table.Replace(loaded)
+			log.Printf("nls: reloaded message files in %s\n", dir)
+		}
+	}()
+	return nil
+}
+
+// fingerprint returns the names, sizes and modification times of the message files.
+func fingerprint(fsys fs.FS, dir string) (string, error) {
+	b := new(strings.Builder)
+	for _, pattern := range []string{"*.yaml", "*.ftl"} {
+		files, err := fs.Glob(fsys, path.Join(dir, "*", pattern))
+		if err != nil {
+			return "", err
+		}
+		for _, each := range files {
+			info, err := fs.Stat(fsys, each)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(b, "%s:%d:%d\n", each, info.Size(), info.ModTime().UnixNano())
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,61 @@
+package nls
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchTable(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "en", "messages.yaml")
+	if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fileName, []byte("hello: hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	table := NewTable("hello")
+	table.Register(0, "en", "generated")
+	if err := WatchTable(ctx, table, os.DirFS(dir), ".", 10*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if err := WatchTable(ctx, table, os.DirFS(dir), "missing", time.Second); err == nil {
+		t.Error("expected error for missing directory")
+	}
+	l := NewTableLocalizer(table, "en")
+	// not replaced until a file changes
+	time.Sleep(50 * time.Millisecond)
+	if got, want := l.Get("hello"), "generated"; got != want {
+		t.Errorf("got [%s] want [%s]", got, want)
+	}
+	waitFor := func(key, want string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for l.Get(key) != want && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if got := l.Get(key); got != want {
+			t.Errorf("got [%s] want [%s]", got, want)
+		}
+	}
+	if err := os.WriteFile(fileName, []byte("hello: hello again\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("hello", "hello again")
+	// Fluent files are reloaded too
+	if err := os.WriteFile(filepath.Join(dir, "en", "app.ftl"), []byte("welcome = welcome from Fluent\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("welcome", "welcome from Fluent")
+	// a parse error keeps the messages
+	if err := os.WriteFile(fileName, []byte("hello: '{{.broken'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	waitFor("hello", "hello again")
+}